@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+// filter keeps documents dated within [from, to] and balances whose period overlaps it,
+// when accounts are set only their balances and documents paid from or to them are kept.
+type filter struct {
+	from, to *time.Time
+	accounts []string
+}
+
+func (f *filter) apply(result *onec.Result) *onec.Result {
+	res := &onec.Result{
+		ExchangeFile:     result.ExchangeFile,
+		Remainings:       []onec.AccountBalance{},
+		PaymentDocuments: []onec.PaymentDocument{},
+	}
+
+	for _, b := range result.Remainings {
+		if f.account(b.Account) && f.overlaps(b.StartDate, b.EndDate) {
+			res.Remainings = append(res.Remainings, b)
+		}
+	}
+
+	for i := range result.PaymentDocuments {
+		d := &result.PaymentDocuments[i]
+		if (f.account(d.PayerAccount) || f.account(d.ReceiverAccount)) && f.contains(d.Data) {
+			res.PaymentDocuments = append(res.PaymentDocuments, *d)
+		}
+	}
+
+	return res
+}
+
+func (f *filter) account(account string) bool {
+	return len(f.accounts) == 0 || slices.Contains(f.accounts, account)
+}
+
+// contains reports whether the date is within the filter, documents without a date are kept.
+func (f *filter) contains(date *time.Time) bool {
+	return f.overlaps(date, date)
+}
+
+func (f *filter) overlaps(start, end *time.Time) bool {
+	if f.from != nil && end != nil && end.Before(*f.from) {
+		return false
+	}
+
+	return f.to == nil || start == nil || !start.After(*f.to)
+}
+
+func parseDate(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	t := onec.ParseDate(date)
+	if t == nil {
+		return nil, fmt.Errorf("%w, got %q", errDate, date)
+	}
+
+	return t, nil
+}
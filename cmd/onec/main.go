@@ -0,0 +1,179 @@
+// Command onec prints the header, balances and payment documents of a 1CClientBankExchange file
+// (kl_to_1c.txt) as JSON, CSV or XLSX, validation issues of the file go to stderr.
+//
+//	onec [-format json|csv|xlsx] [-table documents|balances|header] [-encoding windows|dos|utf-8]
+//	     [-from DD.MM.YYYY] [-to DD.MM.YYYY] [-account number,...] [-o output] file
+//
+// CSV holds one table chosen by -table, XLSX holds all three on separate sheets.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/parser"
+)
+
+const (
+	exitOK = iota
+	exitError
+)
+
+var (
+	errUsage    = errors.New("usage: onec [flags] file")
+	errDate     = errors.New("date must be DD.MM.YYYY")
+	errEncoding = errors.New("encoding must be windows, dos or utf-8")
+	errFormat   = errors.New("format must be json, csv or xlsx")
+	errTable    = errors.New("table must be documents, balances or header")
+)
+
+type options struct {
+	format   string
+	table    string
+	encoding string
+	output   string
+	filter   filter
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	opts, file, err := parseFlags(args, stderr)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stderr, err)
+		}
+
+		return exitError
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	p := &parser.ExchangeFile{Encoding: opts.encoding}
+
+	report, err := p.Validate(bytes.NewReader(content))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	for _, issue := range report.Issues {
+		fmt.Fprintln(stderr, issue)
+	}
+
+	// идентификаторы нужны только для связи документов с остатками
+	result, err := p.Scan(context.Background(), bytes.NewReader(content), ids.NewCounter(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	if err := output(opts, opts.filter.apply(result), stdout); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	return exitOK
+}
+
+func parseFlags(args []string, stderr io.Writer) (*options, string, error) {
+	opts := &options{}
+
+	var encoding, from, to, accounts string
+
+	flags := flag.NewFlagSet("onec", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.format, "format", "json", "output format: json, csv or xlsx")
+	flags.StringVar(&opts.table, "table", "documents", "CSV table: documents, balances or header")
+	flags.StringVar(
+		&encoding,
+		"encoding",
+		"",
+		"file encoding: windows, dos or utf-8, detected when empty",
+	)
+	flags.StringVar(&from, "from", "", "skip documents dated before DD.MM.YYYY")
+	flags.StringVar(&to, "to", "", "skip documents dated after DD.MM.YYYY")
+	flags.StringVar(
+		&accounts,
+		"account",
+		"",
+		"comma separated accounts of balances and documents to keep",
+	)
+	flags.StringVar(&opts.output, "o", "", "output file, stdout when empty")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, "", err
+	}
+
+	if flags.NArg() != 1 {
+		return nil, "", errUsage
+	}
+
+	var err error
+
+	if opts.encoding, err = encodingName(encoding); err != nil {
+		return nil, "", err
+	}
+
+	switch opts.format {
+	case formatJSON, formatCSV, formatXLSX:
+	default:
+		return nil, "", errFormat
+	}
+
+	switch opts.table {
+	case tableDocuments, tableBalances, tableHeader:
+	default:
+		return nil, "", errTable
+	}
+
+	if opts.filter.from, err = parseDate(from); err != nil {
+		return nil, "", fmt.Errorf("-from: %w", err)
+	}
+
+	if opts.filter.to, err = parseDate(to); err != nil {
+		return nil, "", fmt.Errorf("-to: %w", err)
+	}
+
+	for account := range strings.SplitSeq(accounts, ",") {
+		if account = strings.TrimSpace(account); account != "" {
+			opts.filter.accounts = append(opts.filter.accounts, account)
+		}
+	}
+
+	return opts, flags.Arg(0), nil
+}
+
+// encodingName accepts the values of the Кодировка key and the names of the encodings.
+func encodingName(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return "", nil
+	case "windows", "windows-1251", "cp1251":
+		return onec.EncodingWindows1251, nil
+	case "dos", "cp866":
+		return onec.EncodingCP866, nil
+	case "utf-8", "utf8":
+		return onec.EncodingUTF8, nil
+	default:
+		return "", fmt.Errorf("%w, got %q", errEncoding, name)
+	}
+}
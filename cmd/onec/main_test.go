@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+)
+
+type OnecTestSuite struct {
+	suite.Suite
+
+	file string
+}
+
+func (suite *OnecTestSuite) SetupTest() {
+	suite.file = filepath.Join(suite.T().TempDir(), "kl_to_1c.txt")
+	suite.Require().NoError(os.WriteFile(suite.file, []byte(statement), 0o600))
+}
+
+func (suite *OnecTestSuite) run(args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+
+	code := run(append(args, suite.file), &stdout, &stderr)
+
+	return stdout.String(), stderr.String(), code
+}
+
+func (suite *OnecTestSuite) TestJSON() {
+	stdout, stderr, code := suite.run("-encoding", "utf-8")
+	suite.Require().Equal(exitOK, code, stderr)
+
+	var out struct {
+		Header struct {
+			FormatVer string `json:"format_ver"`
+		} `json:"header"`
+		Balances  []json.RawMessage `json:"balances"`
+		Documents []struct {
+			Number string  `json:"number"`
+			Summ   float64 `json:"summ"`
+		} `json:"documents"`
+	}
+
+	suite.Require().NoError(json.Unmarshal([]byte(stdout), &out))
+	suite.Equal("1.03", out.Header.FormatVer)
+	suite.Len(out.Balances, 1)
+	suite.Require().Len(out.Documents, 2)
+	suite.InDelta(3.5, out.Documents[0].Summ, 0)
+
+	// ИНН получателя в первом документе с неверным контрольным числом
+	suite.Contains(stderr, `invalid INN "7706095015"`)
+}
+
+func (suite *OnecTestSuite) TestCSVFilters() {
+	stdout, stderr, code := suite.run("-format", "csv", "-from", "16.01.2024")
+	suite.Require().Equal(exitOK, code, stderr)
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 2)
+	suite.Equal("СекцияДокумент", records[0][0])
+	suite.Equal("2", records[1][1])
+	suite.Contains(records[1], "5.00")
+
+	stdout, _, code = suite.run(
+		"-format",
+		"csv",
+		"-table",
+		"balances",
+		"-account",
+		"40702810000000009999",
+	)
+	suite.Require().Equal(exitOK, code)
+	suite.Equal(1, strings.Count(stdout, "\n"), "only the header row is left")
+}
+
+func (suite *OnecTestSuite) TestXLSX() {
+	out := filepath.Join(suite.T().TempDir(), "out.xlsx")
+
+	_, stderr, code := suite.run("-format", "xlsx", "-o", out, "-to", "15.01.2024")
+	suite.Require().Equal(exitOK, code, stderr)
+
+	f, err := excelize.OpenFile(out)
+	suite.Require().NoError(err)
+
+	defer f.Close()
+
+	suite.Equal([]string{"Файл", "Остатки", "Документы"}, f.GetSheetList())
+
+	rows, err := f.GetRows("Документы")
+	suite.Require().NoError(err)
+	suite.Len(rows, 2)
+
+	summ, err := f.GetCellValue("Документы", "F2")
+	suite.Require().NoError(err)
+	suite.Equal("3.5", summ)
+}
+
+func (suite *OnecTestSuite) TestFlags() {
+	for _, args := range [][]string{
+		{"-format", "pdf"},
+		{"-table", "totals"},
+		{"-encoding", "koi8-r"},
+		{"-from", "2024-13-01"},
+	} {
+		_, stderr, code := suite.run(args...)
+		suite.Equal(exitError, code, args)
+		suite.NotEmpty(stderr, args)
+	}
+}
+
+func TestOnecTestSuite(t *testing.T) {
+	suite.Run(t, new(OnecTestSuite))
+}
+
+var statement = strings.Join([]string{
+	"1CClientBankExchange",
+	"ВерсияФормата=1.03",
+	"Кодировка=Windows",
+	"ДатаНачала=01.01.2024",
+	"ДатаКонца=31.01.2024",
+	"РасчСчет=40702810000000001234",
+	"СекцияРасчСчет",
+	"ДатаНачала=01.01.2024",
+	"ДатаКонца=31.01.2024",
+	"РасчСчет=40702810000000001234",
+	"НачальныйОстаток=10.00",
+	"ВсегоПоступило=5.00",
+	"ВсегоСписано=3.50",
+	"КонечныйОстаток=11.50",
+	"КонецРасчСчет",
+	"СекцияДокумент=Платежное поручение",
+	"Номер=1",
+	"Дата=15.01.2024",
+	"Сумма=3.50",
+	"ПлательщикСчет=40702810000000001234",
+	"ДатаСписано=15.01.2024",
+	"ПолучательСчет=40702810000000005678",
+	"ПолучательИНН=7706095015",
+	"КонецДокумента",
+	"СекцияДокумент=Платежное поручение",
+	"Номер=2",
+	"Дата=16.01.2024",
+	"Сумма=5.00",
+	"ПлательщикСчет=40702810000000005678",
+	"ПолучательСчет=40702810000000001234",
+	"ДатаПоступило=16.01.2024",
+	"КонецДокумента",
+	"КонецФайла",
+}, "\r\n")
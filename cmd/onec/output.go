@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+
+	tableDocuments = "documents"
+	tableBalances  = "balances"
+	tableHeader    = "header"
+)
+
+// sheets of the XLSX file in the order of the exchange file sections.
+var sheets = []struct {
+	table, name string
+}{
+	{tableHeader, "Файл"},
+	{tableBalances, "Остатки"},
+	{tableDocuments, "Документы"},
+}
+
+func output(opts *options, result *onec.Result, stdout io.Writer) (err error) {
+	w := stdout
+
+	if opts.output != "" {
+		file, err := os.Create(opts.output)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			err = errors.Join(err, file.Close())
+		}()
+
+		w = file
+	}
+
+	switch opts.format {
+	case formatCSV:
+		return writeCSV(w, table(result, opts.table))
+	case formatXLSX:
+		return writeXLSX(w, result)
+	default:
+		return writeJSON(w, result)
+	}
+}
+
+func writeJSON(w io.Writer, result *onec.Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(struct {
+		Header    onec.ExchangeFile      `json:"header"`
+		Balances  []onec.AccountBalance  `json:"balances"`
+		Documents []onec.PaymentDocument `json:"documents"`
+	}{result.ExchangeFile, result.Remainings, result.PaymentDocuments})
+}
+
+func writeCSV(w io.Writer, rows [][]any) error {
+	cw := csv.NewWriter(w)
+
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = fmt.Sprint(v)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func writeXLSX(w io.Writer, result *onec.Result) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet.name); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet.name); err != nil {
+			return err
+		}
+
+		for n, row := range table(result, sheet.table) {
+			for i, v := range row {
+				if m, ok := v.(money.Money); ok {
+					row[i] = m.Float64()
+				}
+			}
+
+			axis, err := excelize.CoordinatesToCellName(1, n+1)
+			if err != nil {
+				return err
+			}
+
+			if err := f.SetSheetRow(sheet.name, axis, &row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+// table returns the rows of a table, the first row holds the keys of the exchange file sections.
+func table(result *onec.Result, name string) [][]any {
+	switch name {
+	case tableHeader:
+		return rows([]onec.ExchangeFile{result.ExchangeFile})
+	case tableBalances:
+		return rows(result.Remainings)
+	default:
+		return rows(result.PaymentDocuments)
+	}
+}
+
+func rows[T any](items []T) [][]any {
+	typ := reflect.TypeFor[T]()
+
+	var (
+		fields []int
+		header []any
+	)
+
+	for i := range typ.NumField() {
+		key, _, _ := strings.Cut(typ.Field(i).Tag.Get("mapstructure"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		fields = append(fields, i)
+		header = append(header, key)
+	}
+
+	res := make([][]any, 0, len(items)+1)
+	res = append(res, header)
+
+	for i := range items {
+		val := reflect.ValueOf(&items[i]).Elem()
+
+		row := make([]any, 0, len(fields))
+		for _, f := range fields {
+			row = append(row, cell(val.Field(f)))
+		}
+
+		res = append(res, row)
+	}
+
+	return res
+}
+
+// cell returns sums as money.Money, XLSX stores them as numbers so spreadsheets can add them up,
+// other values as text.
+func cell(field reflect.Value) any {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return ""
+		}
+
+		field = field.Elem()
+	}
+
+	switch v := field.Interface().(type) {
+	case money.Money:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,115 @@
+// Command onecdiff compares two bank statements, e.g. an uploaded one and the statement re-issued
+// by the bank, and prints added, removed and modified payment documents and balance deltas.
+//
+//	onecdiff [-format text|json] old.txt new.txt
+//
+// Statements are 1CClientBankExchange files, MT940 (.sta, .mt940) or CAMT.053 (.xml) files.
+// The exit code is 0 when statements are equal, 1 when they differ and 2 on errors.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/camt053"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/diff"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/mt940"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/parser"
+)
+
+const (
+	exitEqual = iota
+	exitDiffer
+	exitError
+)
+
+var errUsage = errors.New("usage: onecdiff [-format text|json] old new")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("onecdiff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output format: text or json")
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if flags.NArg() != 2 || (*format != "text" && *format != "json") {
+		fmt.Fprintln(stderr, errUsage)
+
+		return exitError
+	}
+
+	oldResult, err := parse(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	newResult, err := parse(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	d := diff.Compare(oldResult, newResult)
+
+	if *format == "json" {
+		err = d.WriteJSON(stdout)
+	} else {
+		err = d.WriteText(stdout)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	if d.IsEmpty() {
+		return exitEqual
+	}
+
+	return exitDiffer
+}
+
+func parse(name string) (*onec.Result, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// идентификаторы нужны только для связи документов с остатками внутри файла
+	result, err := parserFor(name).Scan(context.Background(), file, ids.NewCounter(0))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return result, nil
+}
+
+func parserFor(name string) onec.Parser {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".sta", ".mt940":
+		return &mt940.Parser{}
+	case ".xml":
+		return &camt053.Parser{}
+	default:
+		return &parser.ExchangeFile{}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/camt053"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/mt940"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/parser"
+)
+
+type OnecdiffTestSuite struct {
+	suite.Suite
+
+	oldFile string
+	newFile string
+}
+
+func (suite *OnecdiffTestSuite) SetupTest() {
+	dir := suite.T().TempDir()
+	suite.oldFile = filepath.Join(dir, "old.txt")
+	suite.newFile = filepath.Join(dir, "new.txt")
+
+	suite.Require().NoError(os.WriteFile(suite.oldFile, []byte(statement("5.00", "11.50")), 0o600))
+	// банк перевыпустил выписку с другой суммой второго документа
+	suite.Require().NoError(os.WriteFile(suite.newFile, []byte(statement("6.00", "12.50")), 0o600))
+}
+
+func (suite *OnecdiffTestSuite) run(args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+
+	code := run(args, &stdout, &stderr)
+
+	return stdout.String(), stderr.String(), code
+}
+
+func (suite *OnecdiffTestSuite) TestEqual() {
+	stdout, stderr, code := suite.run(suite.oldFile, suite.oldFile)
+	suite.Require().Equal(exitEqual, code, stderr)
+	suite.Equal("documents: 0 added, 0 removed, 0 modified\n", stdout)
+}
+
+func (suite *OnecdiffTestSuite) TestText() {
+	stdout, stderr, code := suite.run(suite.oldFile, suite.newFile)
+	suite.Require().Equal(exitDiffer, code, stderr)
+
+	suite.Contains(stdout, "documents: 0 added, 0 removed, 1 modified\n")
+	suite.Contains(stdout, "~ Платежное поручение №2 от 16.01.2024")
+	suite.Contains(stdout, `Сумма: "5.00" -> "6.00"`)
+	suite.Contains(stdout, "~ account 40702810000000001234\n")
+}
+
+func (suite *OnecdiffTestSuite) TestJSON() {
+	stdout, stderr, code := suite.run("-format", "json", suite.oldFile, suite.newFile)
+	suite.Require().Equal(exitDiffer, code, stderr)
+
+	var out struct {
+		Documents []struct {
+			Kind   string `json:"kind"`
+			Fields []struct {
+				Field string `json:"field"`
+				Old   string `json:"old"`
+				New   string `json:"new"`
+			} `json:"fields"`
+		} `json:"documents"`
+		Balances []struct {
+			Account      string  `json:"account"`
+			FinalBalance float64 `json:"final_balance_delta"`
+		} `json:"balances"`
+	}
+
+	suite.Require().NoError(json.Unmarshal([]byte(stdout), &out))
+	suite.Require().Len(out.Documents, 1)
+	suite.Equal("modified", out.Documents[0].Kind)
+	suite.Require().Len(out.Documents[0].Fields, 1)
+	suite.Equal("Сумма", out.Documents[0].Fields[0].Field)
+	suite.Require().Len(out.Balances, 1)
+	suite.Equal("40702810000000001234", out.Balances[0].Account)
+	suite.InDelta(1.0, out.Balances[0].FinalBalance, 0)
+}
+
+func (suite *OnecdiffTestSuite) TestErrors() {
+	for _, args := range [][]string{
+		{suite.oldFile},
+		{suite.oldFile, suite.newFile, suite.newFile},
+		{"-format", "csv", suite.oldFile, suite.newFile},
+		{"-unknown", suite.oldFile, suite.newFile},
+		{suite.oldFile, filepath.Join(suite.T().TempDir(), "missing.txt")},
+	} {
+		_, stderr, code := suite.run(args...)
+		suite.Equal(exitError, code, args)
+		suite.NotEmpty(stderr, args)
+	}
+}
+
+func (suite *OnecdiffTestSuite) TestParserFor() {
+	suite.IsType(&mt940.Parser{}, parserFor("statement.STA"))
+	suite.IsType(&mt940.Parser{}, parserFor("statement.mt940"))
+	suite.IsType(&camt053.Parser{}, parserFor("statement.xml"))
+	suite.IsType(&parser.ExchangeFile{}, parserFor("kl_to_1c.txt"))
+}
+
+func TestOnecdiffTestSuite(t *testing.T) {
+	suite.Run(t, new(OnecdiffTestSuite))
+}
+
+// statement is a file with two documents, summ is the sum of the second one and final is
+// the final balance.
+func statement(summ, final string) string {
+	return strings.Join([]string{
+		"1CClientBankExchange",
+		"ВерсияФормата=1.03",
+		"Кодировка=Windows",
+		"ДатаНачала=01.01.2024",
+		"ДатаКонца=31.01.2024",
+		"РасчСчет=40702810000000001234",
+		"СекцияРасчСчет",
+		"ДатаНачала=01.01.2024",
+		"ДатаКонца=31.01.2024",
+		"РасчСчет=40702810000000001234",
+		"НачальныйОстаток=10.00",
+		"ВсегоПоступило=" + summ,
+		"ВсегоСписано=3.50",
+		"КонечныйОстаток=" + final,
+		"КонецРасчСчет",
+		"СекцияДокумент=Платежное поручение",
+		"Номер=1",
+		"Дата=15.01.2024",
+		"Сумма=3.50",
+		"ПлательщикСчет=40702810000000001234",
+		"ДатаСписано=15.01.2024",
+		"ПолучательСчет=40702810000000005678",
+		"КонецДокумента",
+		"СекцияДокумент=Платежное поручение",
+		"Номер=2",
+		"Дата=16.01.2024",
+		"Сумма=" + summ,
+		"ПлательщикСчет=40702810000000005678",
+		"ПолучательСчет=40702810000000001234",
+		"ДатаПоступило=16.01.2024",
+		"КонецДокумента",
+		"КонецФайла",
+	}, "\r\n")
+}
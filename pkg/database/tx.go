@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	_txRetries = 3
+	_txBackoff = 10 * time.Millisecond
+)
+
+// SQLSTATE codes of errors after which the whole transaction can be repeated.
+const (
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+)
+
+var ErrTxOptions = errors.New("nested transaction cannot change isolation level or access mode")
+
+// Tx is a transaction of a driver.
+type Tx interface {
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+	// Exec runs a statement without arguments, e.g. SAVEPOINT.
+	Exec(ctx context.Context, query string) error
+}
+
+// Beginner begins transactions, GormDB, SqlxDB and PgxDB adapt the drivers.
+type Beginner interface {
+	Begin(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+}
+
+// TxManager runs units of work in transactions stored in the context, so repositories
+// built on the same Beginner pick the transaction up from the context.
+type TxManager struct {
+	db      Beginner
+	retries int
+	backoff time.Duration
+}
+
+type TxOption func(*TxManager)
+
+// WithTxRetries sets how many times a transaction is repeated after a serialization
+// failure or a deadlock, 0 disables retries.
+func WithTxRetries(n int) TxOption {
+	return func(m *TxManager) {
+		m.retries = n
+	}
+}
+
+// WithTxBackoff sets the pause before the first retry, it doubles with every retry.
+func WithTxBackoff(d time.Duration) TxOption {
+	return func(m *TxManager) {
+		m.backoff = d
+	}
+}
+
+func NewTxManager(db Beginner, opts ...TxOption) *TxManager {
+	m := &TxManager{db: db, retries: _txRetries, backoff: _txBackoff}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// txKey stores the transaction of a Beginner in the context.
+type txKey struct {
+	db Beginner
+}
+
+type txState struct {
+	tx    Tx
+	opts  sql.TxOptions
+	depth int
+}
+
+func stateFrom(ctx context.Context, db Beginner) (*txState, bool) {
+	state, ok := ctx.Value(txKey{db}).(*txState)
+
+	return state, ok
+}
+
+// Do runs fn in a transaction with the default options, see DoWith.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.DoWith(ctx, nil, fn)
+}
+
+// DoWith runs fn in a transaction: it is committed when fn returns nil and rolled back
+// on an error or a panic. Inside another transaction fn runs in a savepoint and inherits
+// the options, which it may not change. The outermost transaction is repeated after
+// a serialization failure or a deadlock, so fn must not have effects outside the database.
+func (m *TxManager) DoWith(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	fn func(ctx context.Context) error,
+) error {
+	if state, ok := stateFrom(ctx, m.db); ok {
+		return m.nested(ctx, state, opts, fn)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.run(ctx, opts, fn)
+		if err == nil || attempt >= m.retries || !IsRetryable(err) {
+			return err
+		}
+
+		// пауза с джиттером, чтобы конкурирующие транзакции не столкнулись снова
+		pause := m.backoff<<attempt + rand.N(m.backoff+1) //nolint:gosec // джиттер не криптография
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(pause):
+		}
+	}
+}
+
+func (m *TxManager) run(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	fn func(ctx context.Context) error,
+) (err error) {
+	tx, err := m.db.Begin(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	state := &txState{tx: tx}
+	if opts != nil {
+		state.opts = *opts
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{m.db}, state)); err != nil {
+		if rbErr := tx.Rollback(context.WithoutCancel(ctx)); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to rollback transaction: %w", rbErr))
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (m *TxManager) nested(
+	ctx context.Context,
+	state *txState,
+	opts *sql.TxOptions,
+	fn func(ctx context.Context) error,
+) (err error) {
+	// пустые опции наследуют опции внешней транзакции
+	if opts != nil && *opts != (sql.TxOptions{}) && *opts != state.opts {
+		return ErrTxOptions
+	}
+
+	savepoint := fmt.Sprintf("sp_%d", state.depth+1)
+
+	if err := state.tx.Exec(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	rollback := func() error {
+		return state.tx.Exec(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT "+savepoint)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = rollback()
+
+			panic(p)
+		}
+	}()
+
+	child := &txState{tx: state.tx, opts: state.opts, depth: state.depth + 1}
+
+	if err := fn(context.WithValue(ctx, txKey{m.db}, child)); err != nil {
+		if rbErr := rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to rollback to savepoint: %w", rbErr))
+		}
+
+		return err
+	}
+
+	if err := state.tx.Exec(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// IsRetryable reports whether the error is a serialization failure or a deadlock,
+// after which the transaction can be repeated.
+func IsRetryable(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	code := pgErr.SQLState()
+
+	return code == SerializationFailure || code == DeadlockDetected
+}
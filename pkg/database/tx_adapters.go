@@ -0,0 +1,195 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+	"gorm.io/gorm"
+)
+
+var ErrIsolation = errors.New("unsupported isolation level")
+
+// ── gorm ──────────────────────────────────────────────────────────────
+
+// GormDB gives gorm repositories the transaction of the context.
+type GormDB struct {
+	db *gorm.DB
+}
+
+func NewGormDB(db *gorm.DB) *GormDB {
+	return &GormDB{db: db}
+}
+
+// Begin implements Beginner.
+func (g *GormDB) Begin(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx := g.db.WithContext(ctx).Begin(opts)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return gormTx{tx}, nil
+}
+
+// DB returns the transaction of the context or the database outside of transactions.
+func (g *GormDB) DB(ctx context.Context) *gorm.DB {
+	if state, ok := stateFrom(ctx, g); ok {
+		return state.tx.(gormTx).db.WithContext(ctx)
+	}
+
+	return g.db.WithContext(ctx)
+}
+
+type gormTx struct {
+	db *gorm.DB
+}
+
+func (t gormTx) Commit(context.Context) error {
+	return t.db.Commit().Error
+}
+
+func (t gormTx) Rollback(context.Context) error {
+	return t.db.Rollback().Error
+}
+
+func (t gormTx) Exec(ctx context.Context, query string) error {
+	return t.db.WithContext(ctx).Exec(query).Error
+}
+
+// ── sqlx ──────────────────────────────────────────────────────────────
+
+// SqlxDB gives sqlx repositories the transaction of the context.
+type SqlxDB struct {
+	db *sqlx.DB
+}
+
+func NewSqlxDB(db *sqlx.DB) *SqlxDB {
+	return &SqlxDB{db: db}
+}
+
+// Begin implements Beginner.
+func (s *SqlxDB) Begin(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := s.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlxTx{tx}, nil
+}
+
+// DB returns the transaction of the context or the database outside of transactions,
+// use it with sqlx.GetContext, sqlx.SelectContext and NamedExecContext.
+func (s *SqlxDB) DB(ctx context.Context) sqlx.ExtContext {
+	if state, ok := stateFrom(ctx, s); ok {
+		return state.tx.(sqlxTx).tx
+	}
+
+	return s.db
+}
+
+type sqlxTx struct {
+	tx *sqlx.Tx
+}
+
+func (t sqlxTx) Commit(context.Context) error {
+	return t.tx.Commit()
+}
+
+func (t sqlxTx) Rollback(context.Context) error {
+	return t.tx.Rollback()
+}
+
+func (t sqlxTx) Exec(ctx context.Context, query string) error {
+	_, err := t.tx.ExecContext(ctx, query)
+
+	return err
+}
+
+// ── pgx ───────────────────────────────────────────────────────────────
+
+// PgxQuerier is the part of pgxpool.Pool and pgx.Tx used by repositories.
+type PgxQuerier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(
+		ctx context.Context,
+		tableName pgx.Identifier,
+		columnNames []string,
+		rowSrc pgx.CopyFromSource,
+	) (int64, error)
+}
+
+// PgxDB gives pgx repositories the transaction of the context.
+type PgxDB struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxDB(pool *pgxpool.Pool) *PgxDB {
+	return &PgxDB{pool: pool}
+}
+
+var pgxIsoLevels = map[sql.IsolationLevel]pgx.TxIsoLevel{
+	sql.LevelDefault:         "",
+	sql.LevelReadUncommitted: pgx.ReadUncommitted,
+	sql.LevelReadCommitted:   pgx.ReadCommitted,
+	sql.LevelRepeatableRead:  pgx.RepeatableRead,
+	sql.LevelSerializable:    pgx.Serializable,
+}
+
+// Begin implements Beginner.
+func (p *PgxDB) Begin(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	var txOpts pgx.TxOptions
+
+	if opts != nil {
+		level, ok := pgxIsoLevels[opts.Isolation]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrIsolation, opts.Isolation)
+		}
+
+		txOpts.IsoLevel = level
+		if opts.ReadOnly {
+			txOpts.AccessMode = pgx.ReadOnly
+		}
+	}
+
+	tx, err := p.pool.BeginTx(ctx, txOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgxTx{tx}, nil
+}
+
+// DB returns the transaction of the context or the pool outside of transactions.
+func (p *PgxDB) DB(ctx context.Context) PgxQuerier {
+	if state, ok := stateFrom(ctx, p); ok {
+		return state.tx.(pgxTx).tx
+	}
+
+	return p.pool
+}
+
+type pgxTx struct {
+	tx pgx.Tx
+}
+
+func (t pgxTx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+func (t pgxTx) Rollback(ctx context.Context) error {
+	return t.tx.Rollback(ctx)
+}
+
+func (t pgxTx) Exec(ctx context.Context, query string) error {
+	_, err := t.tx.Exec(ctx, query)
+
+	return err
+}
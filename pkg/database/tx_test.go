@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type pgError string
+
+func (e pgError) Error() string    { return "pg error " + string(e) }
+func (e pgError) SQLState() string { return string(e) }
+
+// fakeDB logs the statements of its transactions.
+type fakeDB struct {
+	log        []string
+	commitErrs []error
+}
+
+func (f *fakeDB) Begin(_ context.Context, opts *sql.TxOptions) (Tx, error) {
+	stmt := "BEGIN"
+	if opts != nil && opts.Isolation != sql.LevelDefault {
+		stmt += " " + opts.Isolation.String()
+	}
+
+	f.log = append(f.log, stmt)
+
+	return fakeTx{f}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (t fakeTx) Commit(context.Context) error {
+	t.db.log = append(t.db.log, "COMMIT")
+
+	if len(t.db.commitErrs) > 0 {
+		err := t.db.commitErrs[0]
+		t.db.commitErrs = t.db.commitErrs[1:]
+
+		return err
+	}
+
+	return nil
+}
+
+func (t fakeTx) Rollback(context.Context) error {
+	t.db.log = append(t.db.log, "ROLLBACK")
+
+	return nil
+}
+
+func (t fakeTx) Exec(_ context.Context, query string) error {
+	t.db.log = append(t.db.log, query)
+
+	return nil
+}
+
+func TestTxManager(t *testing.T) {
+	errFail := errors.New("fail")
+
+	cases := []struct {
+		name    string
+		retries int
+		commits []error
+		fn      func(m *TxManager) func(ctx context.Context) error
+		err     error
+		log     []string
+	}{
+		{
+			name: "commit",
+			fn: func(*TxManager) func(ctx context.Context) error {
+				return func(context.Context) error { return nil }
+			},
+			log: []string{"BEGIN", "COMMIT"},
+		},
+		{
+			name: "rollback",
+			fn: func(*TxManager) func(ctx context.Context) error {
+				return func(context.Context) error { return errFail }
+			},
+			err: errFail,
+			log: []string{"BEGIN", "ROLLBACK"},
+		},
+		{
+			name: "savepoints",
+			fn: func(m *TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := m.Do(ctx, func(ctx context.Context) error {
+						return m.Do(ctx, func(context.Context) error { return nil })
+					}); err != nil {
+						return err
+					}
+
+					// ошибка вложенной транзакции откатывает только её
+					_ = m.Do(ctx, func(context.Context) error { return errFail })
+
+					return nil
+				}
+			},
+			log: []string{
+				"BEGIN",
+				"SAVEPOINT sp_1", "SAVEPOINT sp_2", "RELEASE SAVEPOINT sp_2", "RELEASE SAVEPOINT sp_1",
+				"SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1",
+				"COMMIT",
+			},
+		},
+		{
+			name: "nested options",
+			fn: func(m *TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return m.DoWith(
+						ctx,
+						&sql.TxOptions{Isolation: sql.LevelSerializable},
+						func(context.Context) error { return nil },
+					)
+				}
+			},
+			err: ErrTxOptions,
+			log: []string{"BEGIN", "ROLLBACK"},
+		},
+		{
+			name:    "retry",
+			retries: 3,
+			commits: []error{
+				pgError(SerializationFailure),
+				fmt.Errorf("wrapped: %w", pgError(DeadlockDetected)),
+			},
+			fn: func(*TxManager) func(ctx context.Context) error {
+				return func(context.Context) error { return nil }
+			},
+			log: []string{"BEGIN", "COMMIT", "BEGIN", "COMMIT", "BEGIN", "COMMIT"},
+		},
+		{
+			name:    "retries exhausted",
+			retries: 1,
+			fn: func(*TxManager) func(ctx context.Context) error {
+				return func(context.Context) error { return pgError(SerializationFailure) }
+			},
+			err: pgError(SerializationFailure),
+			log: []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK"},
+		},
+		{
+			name:    "not retryable",
+			retries: 3,
+			fn: func(*TxManager) func(ctx context.Context) error {
+				return func(context.Context) error { return pgError("23505") }
+			},
+			err: pgError("23505"),
+			log: []string{"BEGIN", "ROLLBACK"},
+		},
+	}
+
+	for _, c := range cases {
+		db := &fakeDB{commitErrs: c.commits}
+		m := NewTxManager(db, WithTxRetries(c.retries), WithTxBackoff(0))
+
+		err := m.Do(context.Background(), c.fn(m))
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: got %v; want %v", c.name, err, c.err)
+		}
+
+		if !reflect.DeepEqual(db.log, c.log) {
+			t.Errorf("%s: got %q; want %q", c.name, db.log, c.log)
+		}
+	}
+}
+
+func TestTxManagerOptions(t *testing.T) {
+	db := &fakeDB{}
+	m := NewTxManager(db)
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+
+	err := m.DoWith(context.Background(), opts, func(ctx context.Context) error {
+		// вложенная транзакция может повторить опции или унаследовать их
+		return m.DoWith(ctx, &sql.TxOptions{}, func(ctx context.Context) error {
+			return m.DoWith(ctx, opts, func(context.Context) error { return nil })
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"BEGIN Serializable",
+		"SAVEPOINT sp_1", "SAVEPOINT sp_2", "RELEASE SAVEPOINT sp_2", "RELEASE SAVEPOINT sp_1",
+		"COMMIT",
+	}
+	if !reflect.DeepEqual(db.log, want) {
+		t.Errorf("got %q; want %q", db.log, want)
+	}
+}
+
+func TestTxManagerPanic(t *testing.T) {
+	db := &fakeDB{}
+	m := NewTxManager(db)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("panic must be repeated")
+		}
+
+		want := []string{"BEGIN", "SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1", "ROLLBACK"}
+		if !reflect.DeepEqual(db.log, want) {
+			t.Errorf("got %q; want %q", db.log, want)
+		}
+	}()
+
+	_ = m.Do(context.Background(), func(ctx context.Context) error {
+		return m.Do(ctx, func(context.Context) error { panic("boom") })
+	})
+}
+
+func TestTxManagersAreSeparate(t *testing.T) {
+	first, second := &fakeDB{}, &fakeDB{}
+	m1, m2 := NewTxManager(first), NewTxManager(second)
+
+	err := m1.Do(context.Background(), func(ctx context.Context) error {
+		if _, ok := stateFrom(ctx, second); ok {
+			t.Error("transaction of another database in the context")
+		}
+
+		return m2.Do(ctx, func(context.Context) error { return nil })
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(second.log, []string{"BEGIN", "COMMIT"}) {
+		t.Errorf("got %q", second.log)
+	}
+}
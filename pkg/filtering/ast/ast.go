@@ -0,0 +1,397 @@
+// Package ast is a backend-neutral tree of AG Grid filter models. A model is parsed once
+// and rendered by raw_filtering, squirrel_fltering and gorm_filtering or evaluated in memory
+// by Eval, so every backend treats filters the same way.
+package ast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+)
+
+var (
+	ErrField      = errors.New("invalid filter field")
+	ErrFilterType = errors.New("unknown filterType")
+	ErrType       = errors.New("unknown filter type")
+	ErrOperator   = errors.New("operator must be AND or OR")
+	ErrValue      = errors.New("missing filter value")
+)
+
+// Kind is the filterType of a condition.
+type Kind string
+
+const (
+	Text   Kind = "text"
+	Number Kind = "number"
+	Date   Kind = "date"
+	Set    Kind = "set"
+	// Search is full-text or trigram search, see Match and Similar.
+	Search Kind = "search"
+)
+
+// Op is the operator of a condition, named after the filter types of AG Grid.
+type Op string
+
+const (
+	Equals             Op = "equals"
+	NotEqual           Op = "notEqual"
+	GreaterThan        Op = "greaterThan"
+	GreaterThanOrEqual Op = "greaterThanOrEqual"
+	LessThan           Op = "lessThan"
+	LessThanOrEqual    Op = "lessThanOrEqual"
+	InRange            Op = "inRange"
+	Contains           Op = "contains"
+	NotContains        Op = "notContains"
+	StartsWith         Op = "startsWith"
+	EndsWith           Op = "endsWith"
+	Blank              Op = "blank"
+	NotBlank           Op = "notBlank"
+	// In matches one of the values of a set filter.
+	In Op = "in"
+	// Match is full-text search with websearch_to_tsquery, the default of search filters.
+	Match Op = "match"
+	// Similar is fuzzy search by word_similarity of pg_trgm.
+	Similar Op = "similar"
+)
+
+// ops are the operators allowed for each kind.
+var ops = map[Kind][]Op{
+	Text: {Equals, NotEqual, Contains, NotContains, StartsWith, EndsWith, Blank, NotBlank},
+	Number: {
+		Equals, NotEqual, GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual,
+		InRange, Blank, NotBlank,
+	},
+	Date: {
+		Equals, NotEqual, GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual,
+		InRange, Blank, NotBlank,
+	},
+	Search: {Match, Similar},
+}
+
+// Logic joins the nodes of a group.
+type Logic string
+
+const (
+	And Logic = "AND"
+	Or  Logic = "OR"
+)
+
+// Node is a Cond or a Group.
+type Node interface {
+	node()
+}
+
+// Cond is a condition on a column.
+type Cond struct {
+	// Column is the column ID of the grid, used by Eval.
+	Column string
+	// Field is the SQL expression of the column, used by SQL renderers.
+	Field string
+	Kind  Kind
+	Op    Op
+	// Values are the operands: strings for text and search, YYYY-MM-DD strings for dates,
+	// numbers as sent by the grid, two values for InRange, set values for In.
+	Values []any
+	// Null makes In match NULL too, a set filter with an empty value selects it.
+	Null bool
+}
+
+// Group joins nodes with AND or OR, an empty group matches everything.
+type Group struct {
+	Logic Logic
+	Nodes []Node
+}
+
+func (*Cond) node()  {}
+func (*Group) node() {}
+
+// Parse builds the tree of the filter model, fields are sorted and joined with AND.
+// Field names must be identifiers, prefix is applied to fields without a table alias.
+func Parse(fm filtering.FilterModel, prefix string) (*Group, error) {
+	res := &Group{Logic: And}
+
+	for _, column := range sortedKeys(fm) {
+		if fm[column].IsEmpty() {
+			continue
+		}
+
+		if !filtering.ValidField(column) {
+			return nil, fmt.Errorf("%w: %q", ErrField, column)
+		}
+
+		field := column
+		if prefix != "" && !strings.Contains(column, ".") {
+			field = prefix + "." + column
+		}
+
+		node, err := ParseFilter(column, field, fm[column])
+		if err != nil {
+			return nil, err
+		}
+
+		if node != nil {
+			res.Nodes = append(res.Nodes, node)
+		}
+	}
+
+	return res, nil
+}
+
+// ParseSchema builds the tree of the filter model on the columns of the schema.
+func ParseSchema(fm filtering.FilterModel, schema *filtering.Schema) (*Group, error) {
+	fields, err := schema.Fields(fm)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Group{Logic: And}
+
+	for _, f := range fields {
+		node, err := ParseFilter(f.Column, f.Expr, f.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if node != nil {
+			res.Nodes = append(res.Nodes, node)
+		}
+	}
+
+	return res, nil
+}
+
+// ParseFilter builds the node of a column filter, field is its SQL expression.
+// The node is nil for an empty set filter.
+func ParseFilter(column, field string, f filtering.Filter) (Node, error) {
+	node, err := parseCombined(column, field, "", f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", column, err)
+	}
+
+	return node, nil
+}
+
+// parseCombined parses a filter whose conditions may be combined again, kind is inherited
+// by conditions without filterType.
+func parseCombined(column, field string, kind Kind, f filtering.Filter) (Node, error) {
+	if f.FilterType != nil {
+		kind = Kind(*f.FilterType)
+	}
+
+	if f.Operator == nil && len(f.Conditions) == 0 {
+		return parseCond(column, field, kind, f)
+	}
+
+	// operator у массива conditions по умолчанию AND
+	logic := And
+	if f.Operator != nil {
+		logic = Logic(strings.ToUpper(*f.Operator))
+	}
+
+	if logic != And && logic != Or {
+		return nil, fmt.Errorf("%w, got %q", ErrOperator, *f.Operator)
+	}
+
+	conditions := f.Combined()
+	if len(f.Conditions) == 0 && len(conditions) < 2 {
+		return nil, fmt.Errorf("%w: condition1 and condition2", ErrValue)
+	}
+
+	res := &Group{Logic: logic}
+
+	for _, c := range conditions {
+		if c == nil {
+			return nil, fmt.Errorf("%w: condition", ErrValue)
+		}
+
+		node, err := parseCombined(column, field, kind, c.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if node != nil {
+			res.Nodes = append(res.Nodes, node)
+		}
+	}
+
+	return res, nil
+}
+
+func parseCond(column, field string, kind Kind, f filtering.Filter) (Node, error) {
+	if kind == Set {
+		return parseSet(column, field, f.Values), nil
+	}
+
+	allowed, ok := ops[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrFilterType, kind)
+	}
+
+	op := Op(deref(f.Type))
+	if kind == Search && op == "" {
+		op = Match
+	}
+
+	if !slices.Contains(allowed, op) {
+		return nil, fmt.Errorf("%w %q", ErrType, op)
+	}
+
+	cond := &Cond{Column: column, Field: field, Kind: kind, Op: op}
+	if op == Blank || op == NotBlank {
+		return cond, nil
+	}
+
+	var err error
+
+	switch kind {
+	case Text:
+		if f.Filter == nil {
+			return nil, fmt.Errorf("%w: filter", ErrValue)
+		}
+
+		cond.Values = []any{fmt.Sprint(*f.Filter)}
+	case Search:
+		if f.Filter == nil || strings.TrimSpace(fmt.Sprint(*f.Filter)) == "" {
+			return nil, fmt.Errorf("%w: filter", ErrValue)
+		}
+
+		cond.Values = []any{strings.TrimSpace(fmt.Sprint(*f.Filter))}
+	case Number:
+		cond.Values, err = numbers(op, f.Filter, f.FilterTo)
+	case Date:
+		cond.Values, err = dates(op, f.DateFrom, f.DateTo)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cond, nil
+}
+
+func parseSet(column, field string, vals []*string) Node {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	cond := &Cond{Column: column, Field: field, Kind: Set, Op: In}
+
+	for _, v := range vals {
+		if v == nil || *v == "" {
+			cond.Null = true
+		} else {
+			cond.Values = append(cond.Values, *v)
+		}
+	}
+
+	return cond
+}
+
+func numbers(op Op, from, to *any) ([]any, error) {
+	if from == nil {
+		return nil, fmt.Errorf("%w: filter", ErrValue)
+	}
+
+	if _, ok := Float(*from); !ok {
+		return nil, fmt.Errorf("%w: filter is not a number", ErrValue)
+	}
+
+	if op != InRange {
+		return []any{*from}, nil
+	}
+
+	if to == nil {
+		return nil, fmt.Errorf("%w: filterTo", ErrValue)
+	}
+
+	if _, ok := Float(*to); !ok {
+		return nil, fmt.Errorf("%w: filterTo is not a number", ErrValue)
+	}
+
+	return []any{*from, *to}, nil
+}
+
+func dates(op Op, from, to *string) ([]any, error) {
+	start := sliceDate(from)
+	if start == "" {
+		return nil, fmt.Errorf("%w: dateFrom", ErrValue)
+	}
+
+	if op != InRange {
+		return []any{start}, nil
+	}
+
+	end := sliceDate(to)
+	if end == "" {
+		return nil, fmt.Errorf("%w: dateTo", ErrValue)
+	}
+
+	return []any{start, end}, nil
+}
+
+// Float converts numbers, numeric strings and json.Number to float64.
+func Float(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+
+		return f, err == nil
+	case interface{ Float64() float64 }:
+		return n.Float64(), true
+	default:
+		return 0, false
+	}
+}
+
+// sliceDate returns YYYY-MM-DD of the date sent by the grid.
+func sliceDate(src *string) string {
+	if src == nil || len(*src) < 10 {
+		return ""
+	}
+
+	return (*src)[:10]
+}
+
+func sortedKeys(fm filtering.FilterModel) []string {
+	keys := make([]string, 0, len(fm))
+	for k := range fm {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func deref[T any](s *T) T {
+	if s == nil {
+		return *new(T)
+	}
+
+	return *s
+}
@@ -0,0 +1,325 @@
+package ast
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+)
+
+func strptr(s string) *string { return &s }
+
+func anyptr(v any) *any { return &v }
+
+func TestParse(t *testing.T) {
+	fm := filtering.FilterModel{
+		"name": {
+			FilterType: strptr("text"),
+			Operator:   strptr("or"),
+			Condition1: &filtering.Condition{Filter: filtering.Filter{
+				Type:   strptr("startsWith"),
+				Filter: anyptr("A"),
+			}},
+			Condition2: &filtering.Condition{Filter: filtering.Filter{Type: strptr("blank")}},
+		},
+		"status": {FilterType: strptr("set"), Values: []*string{strptr("new"), nil}},
+		"empty":  {FilterType: strptr("set"), Values: []*string{}},
+		"d.sum": {
+			FilterType: strptr("number"),
+			Type:       strptr("inRange"),
+			Filter:     anyptr(1),
+			FilterTo:   anyptr("10"),
+		},
+	}
+
+	tree, err := Parse(fm, "pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Group{Logic: And, Nodes: []Node{
+		&Cond{
+			Column: "d.sum",
+			Field:  "d.sum",
+			Kind:   Number,
+			Op:     InRange,
+			Values: []any{1, "10"},
+		},
+		&Group{Logic: Or, Nodes: []Node{
+			&Cond{Column: "name", Field: "pd.name", Kind: Text, Op: StartsWith, Values: []any{"A"}},
+			&Cond{Column: "name", Field: "pd.name", Kind: Text, Op: Blank},
+		}},
+		&Cond{
+			Column: "status",
+			Field:  "pd.status",
+			Kind:   Set,
+			Op:     In,
+			Values: []any{"new"},
+			Null:   true,
+		},
+	}}
+
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("got %#v; want %#v", tree, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		field string
+		f     filtering.Filter
+		err   error
+	}{
+		{"field", "a; --", filtering.Filter{FilterType: strptr("text")}, ErrField},
+		{"filterType", "f", filtering.Filter{FilterType: strptr("geo")}, ErrFilterType},
+		{
+			"type",
+			"f",
+			filtering.Filter{FilterType: strptr("date"), Type: strptr("contains")},
+			ErrType,
+		},
+		{
+			"dateTo",
+			"f",
+			filtering.Filter{
+				FilterType: strptr("date"),
+				Type:       strptr("inRange"),
+				DateFrom:   strptr("2025-01-01"),
+			},
+			ErrValue,
+		},
+		{
+			"not a number",
+			"f",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Type:       strptr("equals"),
+				Filter:     anyptr("1 OR 1=1"),
+			},
+			ErrValue,
+		},
+		{
+			"operator",
+			"f",
+			filtering.Filter{FilterType: strptr("text"), Operator: strptr("XOR")},
+			ErrOperator,
+		},
+	}
+
+	for _, c := range cases {
+		if _, err := Parse(filtering.FilterModel{c.field: c.f}, ""); !errors.Is(err, c.err) {
+			t.Errorf("%s: got %v; want %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	day := time.Date(2025, 5, 23, 15, 0, 0, 0, time.UTC)
+	sum := money.MustParse("150.00")
+	row := map[string]any{
+		"name":   "Ромашка ООО",
+		"date":   day,
+		"sum":    &sum,
+		"count":  3,
+		"status": "new",
+		"note":   nil,
+	}
+	value := func(column string) any { return row[column] }
+
+	cases := []struct {
+		name string
+		cond *Cond
+		want bool
+	}{
+		{
+			"text contains",
+			&Cond{Column: "name", Kind: Text, Op: Contains, Values: []any{"РОМ"}},
+			true,
+		},
+		{
+			"text endsWith",
+			&Cond{Column: "name", Kind: Text, Op: EndsWith, Values: []any{"ооо"}},
+			true,
+		},
+		{
+			"text notEqual",
+			&Cond{Column: "name", Kind: Text, Op: NotEqual, Values: []any{"x"}},
+			true,
+		},
+		{"text blank", &Cond{Column: "note", Kind: Text, Op: Blank}, true},
+		{
+			"null notEqual",
+			&Cond{Column: "note", Kind: Text, Op: NotEqual, Values: []any{"x"}},
+			false,
+		},
+		{
+			"number inRange",
+			&Cond{Column: "sum", Kind: Number, Op: InRange, Values: []any{100, "200"}},
+			true,
+		},
+		{
+			"number lessThan",
+			&Cond{Column: "count", Kind: Number, Op: LessThan, Values: []any{3.0}},
+			false,
+		},
+		{
+			"date equals",
+			&Cond{Column: "date", Kind: Date, Op: Equals, Values: []any{"2025-05-23"}},
+			true,
+		},
+		{
+			"date greaterThan",
+			&Cond{Column: "date", Kind: Date, Op: GreaterThan, Values: []any{"2025-05-23"}},
+			false,
+		},
+		{"set", &Cond{Column: "status", Kind: Set, Op: In, Values: []any{"old", "new"}}, true},
+		{
+			"set null",
+			&Cond{Column: "note", Kind: Set, Op: In, Values: []any{"a"}, Null: true},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		if got := Eval(c.cond, value); got != c.want {
+			t.Errorf("%s: got %v; want %v", c.name, got, c.want)
+		}
+	}
+
+	or := &Group{Logic: Or, Nodes: []Node{cases[4].cond, cases[0].cond}}
+	if !Eval(or, value) {
+		t.Error("OR group must match")
+	}
+
+	and := &Group{Logic: And, Nodes: []Node{cases[4].cond, cases[0].cond}}
+	if Eval(and, value) {
+		t.Error("AND group must not match")
+	}
+
+	if !Eval(&Group{Logic: Or}, value) {
+		t.Error("empty group must match")
+	}
+}
+
+func TestParseConditions(t *testing.T) {
+	fm, err := filtering.ParseJSONToFilterModel(`{"sum": {
+		"filterType": "number", "operator": "OR",
+		"condition1": {"type": "equals", "filter": 1},
+		"conditions": [
+			{"type": "lessThan", "filter": 0},
+			{"operator": "AND", "conditions": [
+				{"type": "greaterThan", "filter": 10},
+				{"type": "lessThan", "filter": 20},
+				{"type": "notEqual", "filter": 15}
+			]}
+		]
+	}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := Parse(fm, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cond := func(op Op, v float64) *Cond {
+		return &Cond{Column: "sum", Field: "sum", Kind: Number, Op: op, Values: []any{v}}
+	}
+
+	// conditions важнее condition1, вложенные условия наследуют filterType
+	want := &Group{Logic: And, Nodes: []Node{
+		&Group{Logic: Or, Nodes: []Node{
+			cond(LessThan, 0),
+			&Group{Logic: And, Nodes: []Node{
+				cond(GreaterThan, 10),
+				cond(LessThan, 20),
+				cond(NotEqual, 15),
+			}},
+		}},
+	}}
+
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("got %#v; want %#v", tree, want)
+	}
+
+	value := func(v float64) func(string) any {
+		return func(string) any { return v }
+	}
+
+	for v, want := range map[float64]bool{-1: true, 12: true, 15: false, 5: false} {
+		if got := Eval(tree, value(v)); got != want {
+			t.Errorf("Eval(%v) = %v; want %v", v, got, want)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	node, err := ParseFilter("purpose", "pd.purpose", filtering.Filter{
+		FilterType: strptr("search"),
+		Filter:     anyptr("  оплата договора "),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Cond{
+		Column: "purpose",
+		Field:  "pd.purpose",
+		Kind:   Search,
+		Op:     Match,
+		Values: []any{"оплата договора"},
+	}
+	if !reflect.DeepEqual(node, want) {
+		t.Errorf("got %+v; want %+v", node, want)
+	}
+
+	errs := []filtering.Filter{
+		{FilterType: strptr("search"), Filter: anyptr(" ")},
+		{FilterType: strptr("search"), Type: strptr("contains"), Filter: anyptr("x")},
+	}
+	for _, f := range errs {
+		if _, err := ParseFilter("purpose", "purpose", f); !errors.Is(err, ErrValue) &&
+			!errors.Is(err, ErrType) {
+			t.Errorf("%+v: got %v", f, err)
+		}
+	}
+
+	purpose := "Оплата по договору №15 за услуги связи, НДС не облагается"
+	value := func(string) any { return purpose }
+
+	cases := []struct {
+		op    Op
+		query string
+		want  bool
+	}{
+		{Match, "оплата договор", true},
+		{Match, "оплата аренда", false},
+		{Match, "аренда or связи", true},
+		{Match, "оплата -ндс", false},
+		{Match, `"услуги связи"`, true},
+		{Match, `"связи услуги"`, false},
+		{Similar, "договор", true},
+		{Similar, "связ", true},
+		{Similar, "аренда", false},
+	}
+
+	for _, c := range cases {
+		cond := &Cond{Column: "purpose", Kind: Search, Op: c.op, Values: []any{c.query}}
+		if got := Eval(cond, value); got != c.want {
+			t.Errorf("%s %q: got %v; want %v", c.op, c.query, got, c.want)
+		}
+	}
+
+	tree := &Group{Logic: And, Nodes: []Node{
+		&Cond{Column: "name", Kind: Text, Op: Contains, Values: []any{"a"}},
+		&Group{Logic: Or, Nodes: []Node{want}},
+	}}
+	if got := Searches(tree); len(got) != 1 || got[0] != want {
+		t.Errorf("Searches got %v", got)
+	}
+}
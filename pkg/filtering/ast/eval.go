@@ -0,0 +1,166 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Eval reports whether a row matches the node, value returns the value of a column.
+// It follows the SQL renderers: text is compared case-insensitively, dates by day,
+// NULL (a nil value) matches only blank and set filters with an empty value,
+// search is approximated.
+func Eval(node Node, value func(column string) any) bool {
+	switch n := node.(type) {
+	case *Group:
+		return evalGroup(n, value)
+	case *Cond:
+		return evalCond(n, indirect(value(n.Column)))
+	default:
+		return true
+	}
+}
+
+func evalGroup(g *Group, value func(column string) any) bool {
+	for _, node := range g.Nodes {
+		ok := Eval(node, value)
+		if g.Logic == Or && ok {
+			return true
+		}
+
+		if g.Logic != Or && !ok {
+			return false
+		}
+	}
+
+	// пустая группа ничего не отбрасывает
+	return g.Logic != Or || len(g.Nodes) == 0
+}
+
+func evalCond(c *Cond, v any) bool {
+	switch c.Op {
+	case Blank:
+		return v == nil || (c.Kind == Text && fmt.Sprint(v) == "")
+	case NotBlank:
+		return v != nil && (c.Kind != Text || fmt.Sprint(v) != "")
+	}
+
+	if v == nil {
+		return c.Kind == Set && c.Null
+	}
+
+	switch c.Kind {
+	case Text:
+		return evalText(
+			c.Op,
+			strings.ToLower(fmt.Sprint(v)),
+			strings.ToLower(fmt.Sprint(c.Values[0])),
+		)
+	case Number:
+		return evalNumber(c, v)
+	case Date:
+		return evalDate(c, v)
+	case Set:
+		return slices.Contains(c.Values, any(fmt.Sprint(v)))
+	case Search:
+		return evalSearch(c.Op, fmt.Sprint(v), fmt.Sprint(c.Values[0]))
+	default:
+		return false
+	}
+}
+
+func evalText(op Op, s, pattern string) bool {
+	switch op {
+	case Equals:
+		return s == pattern
+	case NotEqual:
+		return s != pattern
+	case Contains:
+		return strings.Contains(s, pattern)
+	case NotContains:
+		return !strings.Contains(s, pattern)
+	case StartsWith:
+		return strings.HasPrefix(s, pattern)
+	case EndsWith:
+		return strings.HasSuffix(s, pattern)
+	default:
+		return false
+	}
+}
+
+func evalNumber(c *Cond, v any) bool {
+	n, ok := Float(v)
+	if !ok {
+		return false
+	}
+
+	operands := make([]float64, len(c.Values))
+	for i, val := range c.Values {
+		operands[i], _ = Float(val)
+	}
+
+	return compare(c.Op, n, operands)
+}
+
+func evalDate(c *Cond, v any) bool {
+	var day string
+
+	switch t := v.(type) {
+	case time.Time:
+		day = t.Format(time.DateOnly)
+	case string:
+		day = sliceDate(&t)
+	}
+
+	if day == "" {
+		return false
+	}
+
+	operands := make([]string, len(c.Values))
+	for i, val := range c.Values {
+		operands[i] = fmt.Sprint(val)
+	}
+
+	return compare(c.Op, day, operands)
+}
+
+func compare[T float64 | string](op Op, v T, operands []T) bool {
+	switch op {
+	case Equals:
+		return v == operands[0]
+	case NotEqual:
+		return v != operands[0]
+	case GreaterThan:
+		return v > operands[0]
+	case GreaterThanOrEqual:
+		return v >= operands[0]
+	case LessThan:
+		return v < operands[0]
+	case LessThanOrEqual:
+		return v <= operands[0]
+	case InRange:
+		return v >= operands[0] && v <= operands[1]
+	default:
+		return false
+	}
+}
+
+// indirect dereferences pointers, a nil pointer is NULL.
+func indirect(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() {
+		return nil
+	}
+
+	return rv.Interface()
+}
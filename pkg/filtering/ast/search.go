@@ -0,0 +1,157 @@
+package ast
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SearchConfig is the text search configuration of full-text search,
+// an index must use the same one: to_tsvector('russian', purpose).
+const SearchConfig = "russian"
+
+// WordSimilarityThreshold is pg_trgm.word_similarity_threshold of Postgres,
+// Eval uses it for Similar.
+const WordSimilarityThreshold = 0.6
+
+// Searches returns the search conditions of the tree in order, for ranking the rows.
+func Searches(node Node) []*Cond {
+	var res []*Cond
+
+	switch n := node.(type) {
+	case *Group:
+		for _, child := range n.Nodes {
+			res = append(res, Searches(child)...)
+		}
+	case *Cond:
+		if n.Kind == Search {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+// evalSearch approximates the search of Postgres: Match follows the syntax of
+// websearch_to_tsquery ("phrase", or, -word) but matches words as substrings without
+// morphology, Similar shares trigrams of pg_trgm with the text as a whole.
+func evalSearch(op Op, s, query string) bool {
+	s = strings.ToLower(s)
+
+	if op == Similar {
+		return wordSimilarity(query, s) >= WordSimilarityThreshold
+	}
+
+	clauses := parseWebSearch(strings.ToLower(query))
+	if len(clauses) == 0 {
+		return false
+	}
+
+	for _, alternatives := range clauses {
+		if !slices.ContainsFunc(
+			alternatives,
+			func(t term) bool { return strings.Contains(s, t.text) != t.not },
+		) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// term is a word or a phrase of a web search query.
+type term struct {
+	text string
+	not  bool
+}
+
+// parseWebSearch splits the query into clauses joined with AND,
+// the terms of a clause are alternatives joined with or.
+func parseWebSearch(query string) [][]term {
+	var (
+		res    [][]term
+		or     bool
+		tokens []string
+	)
+
+	for i, part := range strings.Split(query, `"`) {
+		// нечётные части стоят в кавычках и ищутся целиком
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(part), " "); phrase != "" {
+				tokens = append(tokens, `"`+phrase)
+			}
+
+			continue
+		}
+
+		tokens = append(tokens, strings.Fields(part)...)
+	}
+
+	for _, token := range tokens {
+		if token == "or" {
+			or = len(res) > 0
+
+			continue
+		}
+
+		t := term{text: strings.TrimPrefix(token, `"`)}
+		if !strings.HasPrefix(token, `"`) {
+			t.not = strings.HasPrefix(token, "-")
+			t.text = strings.TrimFunc(token, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+		}
+
+		if t.text == "" {
+			continue
+		}
+
+		if or {
+			res[len(res)-1] = append(res[len(res)-1], t)
+		} else {
+			res = append(res, []term{t})
+		}
+
+		or = false
+	}
+
+	return res
+}
+
+// wordSimilarity is the share of trigrams of the query found in the text.
+func wordSimilarity(query, s string) float64 {
+	want := trigrams(query)
+	if len(want) == 0 {
+		return 0
+	}
+
+	have := trigrams(s)
+	common := 0
+
+	for t := range want {
+		if have[t] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(want))
+}
+
+// trigrams splits the text into words and returns their trigrams like pg_trgm:
+// lower case, two spaces before a word and one after it.
+func trigrams(s string) map[string]bool {
+	res := map[string]bool{}
+
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			res[string(runes[i:i+3])] = true
+		}
+	}
+
+	return res
+}
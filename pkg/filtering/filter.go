@@ -3,6 +3,7 @@ package filtering
 
 import (
 	"encoding/json"
+	"slices"
 )
 
 type Condition struct {
@@ -20,6 +21,9 @@ type Filter struct {
 	FilterTo   *any       `json:"filterTo,omitempty"`
 	Condition1 *Condition `json:"condition1,omitempty"`
 	Condition2 *Condition `json:"condition2,omitempty"`
+	// Conditions are sent by newer AG Grid versions instead of Condition1 and Condition2,
+	// a condition may have its own Operator and Conditions.
+	Conditions []*Condition `json:"conditions,omitempty"`
 }
 
 func (f Filter) IsEmpty() bool {
@@ -28,7 +32,26 @@ func (f Filter) IsEmpty() bool {
 		f.Values == nil &&
 		f.DateFrom == nil && f.DateTo == nil &&
 		f.Filter == nil && f.FilterTo == nil &&
-		f.Condition1 == nil && f.Condition2 == nil
+		f.Condition1 == nil && f.Condition2 == nil &&
+		f.Conditions == nil
+}
+
+// Combined returns the conditions of a combined filter, Conditions if set,
+// otherwise the legacy Condition1 and Condition2.
+func (f Filter) Combined() []*Condition {
+	if len(f.Conditions) > 0 {
+		return f.Conditions
+	}
+
+	res := make([]*Condition, 0, 2)
+
+	for _, c := range []*Condition{f.Condition1, f.Condition2} {
+		if c != nil {
+			res = append(res, c)
+		}
+	}
+
+	return res
 }
 
 type FilterModel map[string]Filter
@@ -42,6 +65,20 @@ func (fm FilterModel) Unmarshal(data []byte) error {
 	return nil
 }
 
+// Without returns a copy of the model without the filters of the columns,
+// e.g. the values of a set filter are listed under the filters of other columns.
+func (fm FilterModel) Without(columns ...string) FilterModel {
+	res := make(FilterModel, len(fm))
+
+	for column, f := range fm {
+		if !slices.Contains(columns, column) {
+			res[column] = f
+		}
+	}
+
+	return res
+}
+
 // SortModel model.
 type SortModel []map[string]string
 
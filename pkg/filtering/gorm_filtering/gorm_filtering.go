@@ -7,8 +7,13 @@ import (
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/keyset"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/raw_filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/serverside"
 )
 
 // CreateOrder func.
@@ -31,6 +36,12 @@ func CreateOrder(tbl *gorm.DB, args ...string) *gorm.DB {
 	}
 
 	for _, sort := range sortModel {
+		// колонка и направление попадают в SQL как есть, поэтому допускаются только
+		// идентификаторы и asc или desc
+		if _, ok := filtering.SortDesc(sort["sort"]); !ok || !filtering.ValidField(sort["colId"]) {
+			continue
+		}
+
 		if strings.Contains(sort["colId"], ".") {
 			tbl = tbl.Order(fmt.Sprintf("%s %s", sort["colId"], sort["sort"]))
 		} else {
@@ -54,210 +65,186 @@ func CreateFilter(ctx context.Context, tbl *gorm.DB, args ...string) *gorm.DB {
 
 	prefix := ""
 
-	if len(args) > 1 && args[1] != "" {
-		prefix = fmt.Sprintf("%s.", args[1])
+	if len(args) > 1 {
+		prefix = args[1]
 	}
 
-	for field, filter := range filterModel {
-		if filter.IsEmpty() {
-			continue
-		}
+	tree, err := ast.Parse(filterModel, prefix)
+	if err != nil {
+		tbl.Logger.Info(ctx, "invalid filterModel: "+err.Error())
 
-		if prefix != "" && !strings.Contains(field, ".") {
-			field = fmt.Sprintf("%s%s", prefix, field)
-		}
+		return tbl
+	}
 
-		var filterOperator string
+	return where(tbl, tree)
+}
 
-		if filter.Operator != nil {
-			filterOperator = *filter.Operator
-		}
+// Render adds the condition of the node to the query, an empty node adds nothing.
+func Render(tbl *gorm.DB, node ast.Node) *gorm.DB {
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
 
-		switch *filter.FilterType {
-		case "set":
-			if len(filter.Values) > 0 {
-				// проверка на наличие в set null значения
-				nullExistInSet := false
-
-				for _, val := range filter.Values {
-					if val == nil || *val == "" {
-						nullExistInSet = true
-
-						break
-					}
-				}
-
-				if nullExistInSet { // если null есть - то добавляем условие OR _ IS NULL
-					tbl = tbl.Where(
-						fmt.Sprintf("%s in (?) OR %s IS NULL", field, field),
-						&filter.Values,
-					)
-				} else {
-					tbl = tbl.Where(fmt.Sprintf("%s in (?)", field), filter.Values)
-				}
-			}
-		case "date":
-			if filterOperator == "" {
-				tbl = constructDateWhere(tbl, field, filterOperator, filter)
-			} else {
-				tbl = constructDateWhere(tbl, field, filterOperator, filter.Condition1.Filter, filter.Condition2.Filter)
-			}
-		case "number":
-			tbl = constructNumberWhere(tbl, field, filter)
-		case "text":
-			if filterOperator == "" {
-				tbl = constructTextWhere(tbl, field, filterOperator, filter)
-			} else {
-				tbl = constructTextWhere(tbl, field, filterOperator, filter.Condition1.Filter, filter.Condition2.Filter)
-			}
-		default:
-			tbl.Logger.Info(ctx, "unknown number filterType: "+*filter.FilterType)
-
-			return tbl
-		}
+	if cond := raw_filtering.Render(node, params); cond != "" {
+		tbl = tbl.Where(cond, params.Args...)
 	}
 
 	return tbl
 }
 
-func constructNumberWhere(tbl *gorm.DB, field string, filter filtering.Filter) *gorm.DB {
-	operators := map[string]string{
-		"equals":             "%s = ?",
-		"notEqual":           "%s <> ?",
-		"greaterThan":        "%s > ?",
-		"greaterThanOrEqual": "%s >= ?",
-		"lessThan":           "%s < ?",
-		"lessThanOrEqual":    "%s <= ?",
-		"inRange":            "%s between ? and ?",
+// where adds the nodes of the group as separate conditions.
+func where(tbl *gorm.DB, tree *ast.Group) *gorm.DB {
+	for _, node := range tree.Nodes {
+		tbl = Render(tbl, node)
 	}
-	tbl = constructQuery(
-		tbl,
-		operators[*filter.Type],
-		"",
-		field,
-		"",
-		fmt.Sprintf("%v", *filter.Filter),
-	)
 
 	return tbl
 }
 
-func constructDateWhere(
-	tbl *gorm.DB,
-	field, operator string,
-	filters ...filtering.Filter,
-) *gorm.DB {
-	var start1, start2 string
-
-	start1 = (*filters[0].DateFrom)[0:10]
+// Rank sorts the rows by the search conditions of the node, the best matches first,
+// other sorts added before it stay in front. Ranks have arguments, so gorm keeps all sorts
+// in one expression, which sorts added after Rank would replace: call it last.
+func Rank(tbl *gorm.DB, node ast.Node) *gorm.DB {
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
 
-	if operator != "" {
-		start2 = (*filters[1].DateFrom)[0:10]
+	ranks := raw_filtering.Rank(node, params)
+	if len(ranks) == 0 {
+		return tbl
 	}
 
-	dateOperators := map[string]string{
-		"inRange":     "DATE(%s) between ? and ?",
-		"equals":      "DATE(%s) = ?",
-		"greaterThan": "DATE(%s) > ?",
-		"lessThan":    "DATE(%s) < ?",
-		"notEqual":    "DATE(%s) <> ?",
-	}
+	expr := clause.Expr{SQL: strings.Join(ranks, ", "), Vars: params.Args}
 
-	if *filters[0].Type == "inRange" {
-		end1 := (*filters[0].DateTo)[0:10]
-		if operator == "" {
-			tbl = tbl.Where(fmt.Sprintf("DATE(%s) between ? and ?", field), start1, end1)
-		} else {
-			end2 := (*filters[1].DateTo)[0:10]
-			tbl = tbl.Where(fmt.Sprintf("DATE(%s) between ? and ? %s DATE(%s) between ? and ?", field, operator, field),
-				start1, end1, start2, end2)
-		}
-	} else {
-		if operator == "" {
-			tbl = constructQuery(
-				tbl,
-				dateOperators[*filters[0].Type],
-				"",
-				field,
-				operator,
-				start1)
-		} else {
-			tbl = constructQuery(
-				tbl,
-				dateOperators[*filters[0].Type],
-				dateOperators[*filters[1].Type],
-				field,
-				operator,
-				start1,
-				start2)
+	if c, ok := tbl.Statement.Clauses[clause.OrderBy{}.Name()]; ok {
+		if prev, ok := c.Expression.(clause.OrderBy); ok &&
+			(len(prev.Columns) > 0 || prev.Expression != nil) {
+			expr.SQL = "?, " + expr.SQL
+			expr.Vars = append([]any{orderExpr{prev}}, expr.Vars...)
 		}
 	}
 
-	return tbl
+	return tbl.Order(clause.OrderBy{Expression: expr})
+}
+
+// orderExpr builds the sorts of an ORDER BY clause without the keyword,
+// clause.OrderBy itself would be built as a whole clause inside an expression.
+type orderExpr struct {
+	by clause.OrderBy
+}
+
+func (e orderExpr) Build(builder clause.Builder) {
+	e.by.Build(builder)
 }
 
-func constructTextWhere(
+// CreateSchemaFilter adds conditions of the filter model JSON on the columns of the schema,
+// values are passed as arguments.
+func CreateSchemaFilter(
 	tbl *gorm.DB,
-	field, operator string,
-	filters ...filtering.Filter,
-) *gorm.DB {
-	operators := map[string]string{
-		"equals":      "lower(%s) = ?",
-		"notEqual":    "lower(%s) <> ?",
-		"contains":    "lower(%s) like ?",
-		"notContains": "lower(%s) not like ?",
-		"startsWith":  "lower(%s) like ?",
-		"endsWith":    "lower(%s) like ?",
+	schema *filtering.Schema,
+	filterModel string,
+) (*gorm.DB, error) {
+	if filterModel == "" {
+		return tbl, nil
 	}
-	if operator == "" {
-		tbl = constructQuery(
-			tbl,
-			operators[*filters[0].Type],
-			"",
-			field,
-			operator,
-			likeMix(*filters[0].Type, fmt.Sprintf("%v", *filters[0].Filter)))
-	} else {
-		tbl = constructQuery(
-			tbl,
-			operators[*filters[0].Type],
-			operators[*filters[1].Type],
-			field,
-			operator,
-			likeMix(*filters[0].Type, (*filters[0].Filter).(string)), //nolint:errcheck
-			likeMix(*filters[1].Type, (*filters[1].Filter).(string))) //nolint:errcheck
+
+	fm, err := filtering.ParseJSONToFilterModel(filterModel)
+	if err != nil {
+		return tbl, err
 	}
 
-	return tbl
+	tree, err := ast.ParseSchema(fm, schema)
+	if err != nil {
+		return tbl, err
+	}
+
+	return where(tbl, tree), nil
 }
 
-func likeMix(typeOperator, filter string) (result string) {
-	likeOperators := map[string]string{
-		"contains":    "%%%s%%",
-		"notContains": "%%%s%%",
-		"startsWith":  "%s%%",
-		"endsWith":    "%%%s",
+// CreateSchemaOrder adds sorts of the sort model JSON on the columns of the schema.
+func CreateSchemaOrder(tbl *gorm.DB, schema *filtering.Schema, sortModel string) (*gorm.DB, error) {
+	if sortModel == "" {
+		return tbl, nil
+	}
+
+	var sm filtering.SortModel
+
+	if err := sm.Unmarshal([]byte(sortModel)); err != nil {
+		return tbl, err
 	}
 
-	likePhrase, ok := likeOperators[typeOperator]
-	if ok {
-		return fmt.Sprintf(likePhrase, strings.ToLower(filter))
+	orders, err := schema.Orders(sm)
+	if err != nil {
+		return tbl, err
+	}
+
+	for _, o := range orders {
+		tbl = tbl.Order(o.String())
 	}
 
-	return filter
+	return tbl, nil
 }
 
-func constructQuery(
-	tbl *gorm.DB,
-	query0, query1, field, operator string,
-	params ...string,
-) *gorm.DB {
-	if operator == "" {
-		tbl = tbl.Where(fmt.Sprintf(query0, field), params[0])
-	} else {
-		query := fmt.Sprintf(query0, field) + " " + operator + " " + fmt.Sprintf(query1, field)
-		tbl = tbl.Where(query, params[0], params[1])
+// ServerSide builds the query of a block of rows of the server-side row model on tbl,
+// which holds the table, joins and the columns of leaf rows, group rows replace the columns.
+func ServerSide(tbl *gorm.DB, q *serverside.Query) *gorm.DB {
+	if q.IsGroup() {
+		// выражения группировки схемы идут в SQL как есть, Group взял бы c.name в кавычки
+		groupBy := make([]clause.Column, len(q.GroupBy))
+		for i, expr := range q.GroupBy {
+			groupBy[i] = clause.Column{Name: expr, Raw: true}
+		}
+
+		tbl = tbl.Select(strings.Join(q.Select, ", ")).Clauses(clause.GroupBy{Columns: groupBy})
+	}
+
+	tbl = where(tbl, q.Where)
+
+	for _, order := range q.OrderBy {
+		tbl = tbl.Order(order)
+	}
+
+	if q.Limit > 0 {
+		tbl = tbl.Limit(int(q.Limit)) //nolint:gosec // размер блока грида
+	}
+
+	if q.Offset > 0 {
+		tbl = tbl.Offset(int(q.Offset)) //nolint:gosec // номер строки грида
+	}
+
+	return tbl
+}
+
+// ServerSideCount builds the query counting all rows of the request on the level of the block,
+// for grids that need the row count instead of lastRow -1; call Count on the result.
+// tbl is not changed, so the same session can build the block with ServerSide.
+func ServerSideCount(tbl *gorm.DB, q *serverside.Query) *gorm.DB {
+	rows := ServerSide(
+		tbl.Session(&gorm.Session{}),
+		&serverside.Query{Select: q.Select, Where: q.Where, GroupBy: q.GroupBy},
+	)
+
+	return tbl.Session(&gorm.Session{NewDB: true}).Table("(?) AS ssrm", rows)
+}
+
+// SetValues builds the query of the distinct values of a set filter, tbl selects the rows
+// without sorting and paging. Pluck "value" into []*string.
+func SetValues(tbl *gorm.DB, v *serverside.Values) *gorm.DB {
+	tbl = where(tbl.Distinct(v.Select), v.Where).Order(v.OrderBy)
+	if v.Limit > 0 {
+		tbl = tbl.Limit(int(v.Limit)) //nolint:gosec // размер списка значений
 	}
 
 	return tbl
 }
+
+// Keyset adds the cursor condition, the sorts and the limit of the page to the query.
+// Rows of a backward page come in reverse order, pass them through keyset.Rows.
+func Keyset(tbl *gorm.DB, page *keyset.Page) *gorm.DB {
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
+	if where := page.Where(params); where != "" {
+		tbl = tbl.Where(where, params.Args...)
+	}
+
+	for _, order := range page.OrderBy() {
+		tbl = tbl.Order(order)
+	}
+
+	return tbl.Limit(int(page.Limit())) //nolint:gosec // размер страницы грида
+}
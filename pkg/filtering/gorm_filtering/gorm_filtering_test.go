@@ -0,0 +1,246 @@
+package gorm_filtering
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/keyset"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/serverside"
+)
+
+// dryRun opens a database that builds statements without connecting.
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestCreateOrder(t *testing.T) {
+	tbl := CreateOrder(dryRun(t).Table("users u"), `[
+		{"colId": "name", "sort": "asc"},
+		{"colId": "id", "sort": "desc; drop table users"},
+		{"colId": "id; drop table x --", "sort": "asc"},
+		{"colId": "owner.age", "sort": "desc"}
+	]`, "u")
+
+	var rows []map[string]any
+
+	stmt := tbl.Find(&rows).Statement
+
+	if want := "SELECT * FROM users u ORDER BY u.name asc,owner.age desc"; stmt.SQL.String() != want {
+		t.Errorf("got %q; want %q", stmt.SQL.String(), want)
+	}
+}
+
+func TestServerSide(t *testing.T) {
+	r, err := serverside.ParseRequest([]byte(`{
+		"startRow": 100, "endRow": 200,
+		"rowGroupCols": [{"id": "country"}],
+		"valueCols": [{"id": "gold", "aggFunc": "sum"}],
+		"filterModel": {"gold": {"filterType": "number", "type": "greaterThan", "filter": 0}},
+		"sortModel": [{"colId": "country", "sort": "asc"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := r.Query(&filtering.Schema{Columns: map[string]filtering.Column{
+		"country": {Expr: "c.name"},
+		"gold":    {Expr: "d.gold", FilterType: "number"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := dryRun(t).
+		Table("medals d").
+		Joins("JOIN countries c ON c.id = d.country_id").
+		Session(&gorm.Session{})
+
+	var rows []map[string]any
+
+	stmt := ServerSide(base, q).Find(&rows).Statement
+
+	want := `SELECT c.name AS "country", SUM(d.gold) AS "gold" FROM medals d ` +
+		`JOIN countries c ON c.id = d.country_id WHERE d.gold > $1 ` +
+		`GROUP BY c.name ORDER BY c.name ASC LIMIT $2 OFFSET $3`
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{float64(0), 101, 100}) {
+		t.Errorf("vars %#v", stmt.Vars)
+	}
+
+	var count int64
+
+	stmt = ServerSideCount(base, q).Count(&count).Statement
+
+	// строки считаются подзапросом уровня блока без сортировки и страниц
+	want = `SELECT count(*) FROM (SELECT c.name AS "country", SUM(d.gold) AS "gold" FROM medals d ` +
+		`JOIN countries c ON c.id = d.country_id WHERE d.gold > $1 GROUP BY c.name) AS ssrm`
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("count got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{float64(0)}) {
+		t.Errorf("count vars %#v", stmt.Vars)
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	pager, err := keyset.New(
+		filtering.SortModel{{"colId": "date", "sort": "desc"}},
+		&filtering.Schema{Columns: map[string]filtering.Column{
+			"id":   {Expr: "r.id"},
+			"date": {Expr: "r.created_at"},
+		}},
+		"id",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := pager.Page("", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, next, err := pager.Tokens(first, true, nil, []any{"2025-05-01", int64(42)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := pager.Page(next, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []map[string]any
+
+	stmt := Keyset(dryRun(t).Table("egrn_requests r").Where("r.user_id = ?", 7), page).
+		Find(&rows).
+		Statement
+
+	// gorm берет условие с OR в скобки и склеивает сортировки без пробела
+	want := "SELECT * FROM egrn_requests r WHERE r.user_id = $1 AND " +
+		"((r.created_at < $2 OR (r.created_at = $3 AND r.id > $4))) " +
+		"ORDER BY r.created_at DESC,r.id ASC LIMIT $5"
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{7, "2025-05-01", "2025-05-01", int64(42), 51}) {
+		t.Errorf("vars %#v", stmt.Vars)
+	}
+}
+
+func TestRank(t *testing.T) {
+	fm, err := filtering.ParseJSONToFilterModel(`{
+		"purpose": {"filterType": "search", "filter": "связь"},
+		"sum": {"filterType": "number", "type": "greaterThan", "filter": 100}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := ast.Parse(fm, "pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbl := where(dryRun(t).Table("payment_documents_combo pd"), tree).
+		Order("pd.date DESC").
+		Order("pd.id DESC")
+
+	var rows []map[string]any
+
+	stmt := Rank(tbl, tree).Find(&rows).Statement
+
+	// сортировки, добавленные до Rank, остаются впереди, как в squirrel_fltering.Rank
+	want := "SELECT * FROM payment_documents_combo pd " +
+		"WHERE to_tsvector('russian', pd.purpose) @@ websearch_to_tsquery('russian', $1) " +
+		"AND pd.sum > $2 ORDER BY pd.date DESC,pd.id DESC, " +
+		"ts_rank(to_tsvector('russian', pd.purpose), websearch_to_tsquery('russian', $3)) DESC"
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{"связь", float64(100), "связь"}) {
+		t.Errorf("vars %#v", stmt.Vars)
+	}
+
+	// ранги второго поиска идут после первого
+	stmt = Rank(Rank(dryRun(t).Table("payment_documents_combo pd"), tree), &ast.Cond{
+		Field:  "pd.payer",
+		Kind:   ast.Search,
+		Op:     ast.Similar,
+		Values: []any{"ромашка"},
+	}).Find(&rows).Statement
+
+	want = "SELECT * FROM payment_documents_combo pd ORDER BY " +
+		"ts_rank(to_tsvector('russian', pd.purpose), websearch_to_tsquery('russian', $1)) DESC, " +
+		"word_similarity($2, pd.payer) DESC"
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	// без поиска сортировки не меняются
+	stmt = Rank(dryRun(t).Table("payment_documents_combo pd").Order("pd.id DESC"), &ast.Group{}).
+		Find(&rows).
+		Statement
+
+	if got := stmt.SQL.String(); got != "SELECT * FROM payment_documents_combo pd ORDER BY pd.id DESC" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	fm, err := filtering.ParseJSONToFilterModel(`{
+		"status": {"filterType": "set", "values": ["new"]},
+		"sum": {"filterType": "number", "type": "equals", "filter": 5}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := serverside.SetValues(&filtering.Schema{Columns: map[string]filtering.Column{
+		"status": {Expr: "pd.status", FilterType: "set"},
+		"sum":    {Expr: "pd.sum", FilterType: "number"},
+	}}, "status", fm, "AB", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []*string
+
+	stmt := SetValues(dryRun(t).Table("payment_documents_combo pd"), v).
+		Pluck("value", &values).
+		Statement
+
+	// выражение значения не берется в кавычки, собственный фильтр колонки не применяется
+	want := "SELECT DISTINCT NULLIF(CAST(pd.status AS text), '') AS value " +
+		"FROM payment_documents_combo pd WHERE pd.sum = $1 " +
+		"AND lower(NULLIF(CAST(pd.status AS text), '')) LIKE $2 " +
+		"ORDER BY value ASC NULLS FIRST LIMIT $3"
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{float64(5), "%ab%", 100}) {
+		t.Errorf("vars %#v", stmt.Vars)
+	}
+}
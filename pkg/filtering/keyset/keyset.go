@@ -0,0 +1,368 @@
+// Package keyset pages large grids by cursors instead of OFFSET: a page continues after
+// the key values of the last row of the previous page, e.g. WHERE (date, id) > ($1, $2).
+// Keys are the sorted columns of the grid followed by a unique tie-breaker column,
+// key columns must not be NULL.
+package keyset
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/raw_filtering"
+)
+
+var (
+	ErrCursor = errors.New("invalid cursor")
+	ErrValues = errors.New("key values do not match the keys")
+	ErrSize   = errors.New("page size must be positive")
+)
+
+// Key is a column of the keyset.
+type Key struct {
+	Column string
+	Expr   string
+	Desc   bool
+}
+
+// Pager builds pages of a sort model.
+type Pager struct {
+	keys []Key
+	// signature binds cursors to the sort, a cursor of another sort is rejected.
+	signature string
+}
+
+// New creates a pager of the sort model, columns are resolved by the schema.
+// The tie-breaker, e.g. the primary key, is sorted ascending after the sorted columns
+// unless the sort model already has it.
+func New(sm filtering.SortModel, schema *filtering.Schema, tieBreaker string) (*Pager, error) {
+	p := &Pager{}
+
+	for _, sort := range sm {
+		orders, err := schema.Orders(filtering.SortModel{sort})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(orders) == 0 || p.has(sort["colId"]) {
+			continue
+		}
+
+		p.keys = append(
+			p.keys,
+			Key{Column: sort["colId"], Expr: orders[0].Expr, Desc: orders[0].Desc},
+		)
+	}
+
+	if !p.has(tieBreaker) {
+		expr, err := schema.Expr(tieBreaker)
+		if err != nil {
+			return nil, err
+		}
+
+		p.keys = append(p.keys, Key{Column: tieBreaker, Expr: expr})
+	}
+
+	parts := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		parts[i] = k.Column
+		if k.Desc {
+			parts[i] += " desc"
+		}
+	}
+
+	p.signature = strings.Join(parts, ",")
+
+	return p, nil
+}
+
+func (p *Pager) has(column string) bool {
+	return slices.ContainsFunc(p.keys, func(k Key) bool { return k.Column == column })
+}
+
+// Keys returns the keys, a row gives its values to the pager in this order.
+func (p *Pager) Keys() []Key {
+	return slices.Clone(p.keys)
+}
+
+// Page is a page of rows after or before a cursor.
+type Page struct {
+	keys []Key
+	// values are the key values of the cursor, nil on the first page.
+	values   []any
+	Backward bool
+	Size     int
+}
+
+// Page returns the page of the token, an empty token is the first page.
+func (p *Pager) Page(token string, size int) (*Page, error) {
+	if size <= 0 {
+		return nil, ErrSize
+	}
+
+	page := &Page{keys: p.keys, Size: size}
+	if token == "" {
+		return page, nil
+	}
+
+	c, err := p.decode(token)
+	if err != nil {
+		return nil, err
+	}
+
+	page.values, page.Backward = c.values, c.backward
+
+	return page, nil
+}
+
+// IsFirst reports whether the page starts from the beginning of the grid.
+func (pg *Page) IsFirst() bool {
+	return pg.values == nil
+}
+
+// Limit is one row more than the page, the extra row tells whether there are more rows.
+func (pg *Page) Limit() uint64 {
+	return uint64(pg.Size) + 1 //nolint:gosec // размер проверен в Pager.Page
+}
+
+// OrderBy returns the sorts of the query, a backward page reads rows in reverse order.
+func (pg *Page) OrderBy() []string {
+	res := make([]string, len(pg.keys))
+
+	for i, k := range pg.keys {
+		res[i] = filtering.Order{Expr: k.Expr, Desc: k.Desc != pg.Backward}.String()
+	}
+
+	return res
+}
+
+// Where returns the keyset condition, values go to params. It is empty on the first page.
+// Keys of one direction are compared as a row, (a, b) > ($1, $2), mixed directions
+// are expanded to a > $1 OR (a = $1 AND b < $2).
+func (pg *Page) Where(params *raw_filtering.Params) string {
+	if pg.values == nil {
+		return ""
+	}
+
+	if pg.sameDirection() {
+		exprs := make([]string, len(pg.keys))
+		holders := make([]string, len(pg.keys))
+
+		for i, k := range pg.keys {
+			exprs[i] = k.Expr
+			holders[i] = params.Add(pg.values[i])
+		}
+
+		if len(exprs) == 1 {
+			return fmt.Sprintf("%s %s %s", exprs[0], pg.op(pg.keys[0]), holders[0])
+		}
+
+		return fmt.Sprintf(
+			"(%s) %s (%s)",
+			strings.Join(exprs, ", "),
+			pg.op(pg.keys[0]),
+			strings.Join(holders, ", "),
+		)
+	}
+
+	ors := make([]string, len(pg.keys))
+
+	for i, k := range pg.keys {
+		ands := make([]string, 0, i+1)
+		for j := range i {
+			ands = append(ands, fmt.Sprintf("%s = %s", pg.keys[j].Expr, params.Add(pg.values[j])))
+		}
+
+		ands = append(ands, fmt.Sprintf("%s %s %s", k.Expr, pg.op(k), params.Add(pg.values[i])))
+
+		ors[i] = strings.Join(ands, " AND ")
+		if len(ands) > 1 {
+			ors[i] = "(" + ors[i] + ")"
+		}
+	}
+
+	return "(" + strings.Join(ors, " OR ") + ")"
+}
+
+func (pg *Page) sameDirection() bool {
+	return !slices.ContainsFunc(pg.keys, func(k Key) bool { return k.Desc != pg.keys[0].Desc })
+}
+
+// op compares a key with the cursor: after the cursor in the sort order, before it backwards.
+func (pg *Page) op(k Key) string {
+	if k.Desc != pg.Backward {
+		return "<"
+	}
+
+	return ">"
+}
+
+// Rows cuts the extra row off the fetched rows and restores the grid order of a backward page,
+// more reports whether there are rows beyond the page in its direction.
+func Rows[T any](pg *Page, rows []T) (res []T, more bool) {
+	more = len(rows) > pg.Size
+	if more {
+		rows = rows[:pg.Size]
+	}
+
+	if pg.Backward {
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+	}
+
+	return rows, more
+}
+
+// Tokens returns the cursors of the previous and the next pages, empty when there is no page.
+// more is returned by Rows, first and last are the key values of the first and the last rows
+// of the page in the grid order.
+func (p *Pager) Tokens(pg *Page, more bool, first, last []any) (prev, next string, err error) {
+	hasPrev, hasNext := !pg.IsFirst(), more
+	if pg.Backward {
+		hasPrev, hasNext = more, true
+	}
+
+	if hasPrev && first != nil {
+		if prev, err = p.encode(cursor{values: first, backward: true}); err != nil {
+			return "", "", err
+		}
+	}
+
+	if hasNext && last != nil {
+		if next, err = p.encode(cursor{values: last}); err != nil {
+			return "", "", err
+		}
+	}
+
+	return prev, next, nil
+}
+
+// ── cursor tokens ─────────────────────────────────────────────────────
+
+type cursor struct {
+	values   []any
+	backward bool
+}
+
+// token is the JSON of a cursor, values keep their types: i, u, f, s, b or t (RFC 3339 time).
+type token struct {
+	Signature string     `json:"k"`
+	Backward  bool       `json:"b,omitempty"`
+	Values    [][]string `json:"v"`
+}
+
+func (p *Pager) encode(c cursor) (string, error) {
+	if len(c.values) != len(p.keys) {
+		return "", fmt.Errorf(
+			"%w: got %d values for %d keys",
+			ErrValues,
+			len(c.values),
+			len(p.keys),
+		)
+	}
+
+	t := token{
+		Signature: p.signature,
+		Backward:  c.backward,
+		Values:    make([][]string, len(c.values)),
+	}
+
+	for i, v := range c.values {
+		typ, s, err := encodeValue(v)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", p.keys[i].Column, err)
+		}
+
+		t.Values[i] = []string{typ, s}
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func (p *Pager) decode(s string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrCursor, err)
+	}
+
+	var t token
+
+	if err := json.Unmarshal(data, &t); err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrCursor, err)
+	}
+
+	if t.Signature != p.signature || len(t.Values) != len(p.keys) {
+		return cursor{}, fmt.Errorf("%w: the sort has changed", ErrCursor)
+	}
+
+	c := cursor{backward: t.Backward, values: make([]any, len(t.Values))}
+
+	for i, v := range t.Values {
+		if len(v) != 2 {
+			return cursor{}, ErrCursor
+		}
+
+		if c.values[i], err = decodeValue(v[0], v[1]); err != nil {
+			return cursor{}, fmt.Errorf("%w: %w", ErrCursor, err)
+		}
+	}
+
+	return c, nil
+}
+
+func encodeValue(v any) (typ, s string, err error) {
+	switch v := v.(type) {
+	case int:
+		return "i", strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return "i", strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return "i", strconv.FormatInt(v, 10), nil
+	case uint:
+		return "u", strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return "u", strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return "u", strconv.FormatUint(v, 10), nil
+	case float64:
+		return "f", strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return "s", v, nil
+	case bool:
+		return "b", strconv.FormatBool(v), nil
+	case time.Time:
+		return "t", v.Format(time.RFC3339Nano), nil
+	default:
+		return "", "", fmt.Errorf("%w: unsupported type %T", ErrValues, v)
+	}
+}
+
+func decodeValue(typ, s string) (any, error) {
+	switch typ {
+	case "i":
+		return strconv.ParseInt(s, 10, 64)
+	case "u":
+		return strconv.ParseUint(s, 10, 64)
+	case "f":
+		return strconv.ParseFloat(s, 64)
+	case "s":
+		return s, nil
+	case "b":
+		return strconv.ParseBool(s)
+	case "t":
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		return nil, fmt.Errorf("unknown value type %q", typ)
+	}
+}
@@ -0,0 +1,205 @@
+package keyset
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/raw_filtering"
+)
+
+var schema = &filtering.Schema{
+	Columns: map[string]filtering.Column{
+		"id":     {Expr: "pd.id"},
+		"date":   {Expr: "pd.date"},
+		"amount": {Expr: "pd.amount"},
+	},
+}
+
+func pager(t *testing.T, sm filtering.SortModel) *Pager {
+	t.Helper()
+
+	p, err := New(sm, schema, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestNew(t *testing.T) {
+	p := pager(t, filtering.SortModel{{"colId": "date", "sort": "desc"}})
+
+	want := []Key{{Column: "date", Expr: "pd.date", Desc: true}, {Column: "id", Expr: "pd.id"}}
+	if !reflect.DeepEqual(p.Keys(), want) {
+		t.Errorf("got %+v, want %+v", p.Keys(), want)
+	}
+
+	// тай-брейкер из модели сортировки не дублируется
+	p = pager(t, filtering.SortModel{{"colId": "id", "sort": "desc"}})
+	if len(p.Keys()) != 1 || !p.Keys()[0].Desc {
+		t.Errorf("got %+v", p.Keys())
+	}
+
+	if _, err := New(nil, schema, "uuid"); !errors.Is(err, filtering.ErrColumn) {
+		t.Errorf("got %v, want ErrColumn", err)
+	}
+
+	if _, err := New(filtering.SortModel{{"colId": "name", "sort": "asc"}}, schema, "id"); !errors.Is(
+		err,
+		filtering.ErrColumn,
+	) {
+		t.Errorf("got %v, want ErrColumn", err)
+	}
+}
+
+func TestPages(t *testing.T) {
+	p := pager(t, filtering.SortModel{{"colId": "date", "sort": "asc"}})
+	date := time.Date(2025, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	first, err := p.Page("", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := &raw_filtering.Params{}
+	if where := first.Where(params); where != "" || !first.IsFirst() {
+		t.Errorf("first page: got %q", where)
+	}
+
+	if !reflect.DeepEqual(first.OrderBy(), []string{"pd.date ASC", "pd.id ASC"}) {
+		t.Errorf("got %v", first.OrderBy())
+	}
+
+	if first.Limit() != 3 {
+		t.Errorf("got limit %d", first.Limit())
+	}
+
+	rows, more := Rows(first, []int{1, 2, 3})
+	if !reflect.DeepEqual(rows, []int{1, 2}) || !more {
+		t.Errorf("got %v, %v", rows, more)
+	}
+
+	prev, next, err := p.Tokens(first, more, []any{date, int64(1)}, []any{date, int64(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prev != "" || next == "" {
+		t.Fatalf("got prev %q, next %q", prev, next)
+	}
+
+	second, err := p.Page(next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params = &raw_filtering.Params{Args: []any{"tenant"}}
+	if where := second.Where(params); where != "(pd.date, pd.id) > ($2, $3)" {
+		t.Errorf("got %q", where)
+	}
+
+	if !reflect.DeepEqual(params.Args, []any{"tenant", date, int64(2)}) {
+		t.Errorf("got %v", params.Args)
+	}
+
+	rows, more = Rows(second, []int{3, 4})
+	if !reflect.DeepEqual(rows, []int{3, 4}) || more {
+		t.Errorf("got %v, %v", rows, more)
+	}
+
+	prev, next, err = p.Tokens(second, more, []any{date, int64(3)}, []any{date, int64(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prev == "" || next != "" {
+		t.Fatalf("got prev %q, next %q", prev, next)
+	}
+
+	back, err := p.Page(prev, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params = &raw_filtering.Params{}
+	if where := back.Where(params); where != "(pd.date, pd.id) < ($1, $2)" || !back.Backward {
+		t.Errorf("got %q", where)
+	}
+
+	if !reflect.DeepEqual(back.OrderBy(), []string{"pd.date DESC", "pd.id DESC"}) {
+		t.Errorf("got %v", back.OrderBy())
+	}
+
+	// строки обратной страницы приходят в обратном порядке
+	rows, more = Rows(back, []int{2, 1})
+	if !reflect.DeepEqual(rows, []int{1, 2}) || more {
+		t.Errorf("got %v, %v", rows, more)
+	}
+
+	prev, next, err = p.Tokens(back, more, []any{date, int64(1)}, []any{date, int64(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prev != "" || next == "" {
+		t.Errorf("got prev %q, next %q", prev, next)
+	}
+}
+
+func TestMixedDirections(t *testing.T) {
+	p := pager(t, filtering.SortModel{{"colId": "amount", "sort": "desc"}})
+
+	token, err := p.encode(cursor{values: []any{150.5, int64(7)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := p.Page(token, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
+
+	want := "(pd.amount < ? OR (pd.amount = ? AND pd.id > ?))"
+	if where := page.Where(params); where != want {
+		t.Errorf("got %q, want %q", where, want)
+	}
+
+	if !reflect.DeepEqual(params.Args, []any{150.5, 150.5, int64(7)}) {
+		t.Errorf("got %v", params.Args)
+	}
+}
+
+func TestCursorErrors(t *testing.T) {
+	p := pager(t, filtering.SortModel{{"colId": "date", "sort": "asc"}})
+
+	token, err := p.encode(cursor{values: []any{"2025-05-01", uint64(1)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := pager(t, filtering.SortModel{{"colId": "date", "sort": "desc"}})
+	if _, err := other.Page(token, 10); !errors.Is(err, ErrCursor) {
+		t.Errorf("sort changed: got %v, want ErrCursor", err)
+	}
+
+	if _, err := p.Page("not a cursor", 10); !errors.Is(err, ErrCursor) {
+		t.Errorf("got %v, want ErrCursor", err)
+	}
+
+	if _, err := p.Page(token, 0); !errors.Is(err, ErrSize) {
+		t.Errorf("got %v, want ErrSize", err)
+	}
+
+	if _, err := p.encode(cursor{values: []any{int64(1)}}); !errors.Is(err, ErrValues) {
+		t.Errorf("got %v, want ErrValues", err)
+	}
+
+	if _, err := p.encode(cursor{values: []any{struct{}{}, int64(1)}}); !errors.Is(err, ErrValues) {
+		t.Errorf("got %v, want ErrValues", err)
+	}
+}
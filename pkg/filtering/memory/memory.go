@@ -0,0 +1,297 @@
+// Package memory applies AG Grid filter and sort models to slices, for grids backed by data
+// that is already in memory, e.g. gRPC responses or parsed onec documents. Filters are
+// evaluated by ast.Eval and follow the SQL backends: text is compared case-insensitively,
+// dates by day, NULL (nil) matches only blank filters and sets with an empty value.
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+)
+
+// Accessor returns the value of a column of an item, ok is false for unknown columns.
+// Nil and nil pointers are NULL.
+type Accessor[T any] func(item *T, column string) (value any, ok bool)
+
+// Tags returns an accessor of struct fields named by the tag, e.g. json or mapstructure,
+// fields without the tag are named by the Go name. Columns of nested structs are
+// joined by a dot: "payer.inn".
+func Tags[T any](tag string) Accessor[T] {
+	fields := map[string][]int{}
+	collect(reflect.TypeFor[T](), tag, "", nil, fields, map[reflect.Type]bool{})
+
+	return func(item *T, column string) (any, bool) {
+		index, ok := fields[column]
+		if !ok {
+			return nil, false
+		}
+
+		v := reflect.ValueOf(item).Elem()
+
+		for _, i := range index {
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return nil, true
+				}
+
+				v = v.Elem()
+			}
+
+			v = v.Field(i)
+		}
+
+		return normalize(v), true
+	}
+}
+
+// collect maps the columns of the struct type to field indexes, embedded structs without
+// a tag are promoted like in encoding/json. seen stops recursive types.
+func collect(
+	typ reflect.Type,
+	tag, prefix string,
+	index []int,
+	fields map[string][]int,
+	seen map[reflect.Type]bool,
+) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		// поля неэкспортируемой встроенной структуры продвигаются, как в encoding/json
+		if !f.IsExported() && (!f.Anonymous || isValue(f.Type)) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		path := append(slices.Clip(index), i)
+
+		if f.Anonymous && name == "" && !isValue(f.Type) {
+			collect(f.Type, tag, prefix, path, fields, seen)
+
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		// поле внешней структуры важнее продвинутого поля встроенной
+		if _, ok := fields[prefix+name]; !ok || len(fields[prefix+name]) > len(path) {
+			fields[prefix+name] = path
+		}
+
+		if !isValue(f.Type) {
+			collect(f.Type, tag, prefix+name+".", path, fields, seen)
+		}
+	}
+}
+
+// isValue reports whether the type is a value of a column rather than a nested struct.
+func isValue(typ reflect.Type) bool {
+	if typ.Implements(reflect.TypeFor[interface{ AsTime() time.Time }]()) ||
+		typ.Implements(reflect.TypeFor[interface{ Float64() float64 }]()) {
+		return true
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() != reflect.Struct || typ == reflect.TypeFor[time.Time]()
+}
+
+// normalize dereferences pointers and converts protobuf timestamps to time.Time.
+func normalize(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		if t, ok := v.Interface().(interface{ AsTime() time.Time }); ok {
+			return t.AsTime()
+		}
+
+		v = v.Elem()
+	}
+
+	return v.Interface()
+}
+
+// Filter returns the items matching the filter model, in their order.
+func Filter[T any](items []T, fm filtering.FilterModel, get Accessor[T]) ([]T, error) {
+	tree, err := ast.Parse(fm, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := check(tree, get); err != nil {
+		return nil, err
+	}
+
+	res := make([]T, 0, len(items))
+
+	for i := range items {
+		value := func(column string) any {
+			v, _ := get(&items[i], column)
+
+			return v
+		}
+
+		if ast.Eval(tree, value) {
+			res = append(res, items[i])
+		}
+	}
+
+	return res, nil
+}
+
+// Sort sorts the items by the sort model in place, the sort is stable.
+// Like Postgres, NULLs go after other values in ascending order and first in descending order.
+func Sort[T any](items []T, sm filtering.SortModel, get Accessor[T]) error {
+	type order struct {
+		column string
+		desc   bool
+	}
+
+	orders := make([]order, 0, len(sm))
+
+	var zero T
+
+	for _, sort := range sm {
+		desc, ok := filtering.SortDesc(sort["sort"])
+		if !ok {
+			return fmt.Errorf("%s: %w, got %q", sort["colId"], filtering.ErrSort, sort["sort"])
+		}
+
+		if _, ok := get(&zero, sort["colId"]); !ok {
+			return fmt.Errorf("%w %q", filtering.ErrColumn, sort["colId"])
+		}
+
+		orders = append(orders, order{column: sort["colId"], desc: desc})
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		for _, o := range orders {
+			va, _ := get(&a, o.column)
+			vb, _ := get(&b, o.column)
+
+			if c := Compare(va, vb); c != 0 {
+				if o.desc {
+					return -c
+				}
+
+				return c
+			}
+		}
+
+		return 0
+	})
+
+	return nil
+}
+
+// Apply filters the items and sorts the result, items are left unchanged.
+func Apply[T any](
+	items []T,
+	fm filtering.FilterModel,
+	sm filtering.SortModel,
+	get Accessor[T],
+) ([]T, error) {
+	res, err := Filter(items, fm, get)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Sort(res, sm, get); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Compare orders values of a column: numbers, times and strings, NULL is greater than
+// any value. Values of other types are compared as text.
+func Compare(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	}
+
+	if ba, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			return cmp.Compare(boolInt(ba), boolInt(bb))
+		}
+	}
+
+	_, aString := a.(string)
+	_, bString := b.(string)
+
+	if !aString && !bString {
+		fa, okA := ast.Float(a)
+		fb, okB := ast.Float(b)
+
+		if okA && okB {
+			return cmp.Compare(fa, fb)
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// check checks that the columns of the tree are known to the accessor.
+func check[T any](node ast.Node, get Accessor[T]) error {
+	var zero T
+
+	switch n := node.(type) {
+	case *ast.Group:
+		for _, child := range n.Nodes {
+			if err := check(child, get); err != nil {
+				return err
+			}
+		}
+	case *ast.Cond:
+		if _, ok := get(&zero, n.Column); !ok {
+			return fmt.Errorf("%w %q", filtering.ErrColumn, n.Column)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+)
+
+type bank struct {
+	BIK string `json:"bik"`
+}
+
+type base struct {
+	ID uint64 `json:"id"`
+}
+
+type document struct {
+	base
+
+	Number  string                 `json:"number"`
+	Date    time.Time              `json:"date"`
+	Paid    *timestamppb.Timestamp `json:"paid"`
+	Sum     money.Money            `json:"sum"`
+	Status  *string                `json:"status"`
+	Bank    *bank                  `json:"bank"`
+	Comment string                 `json:"-"`
+}
+
+func strptr(s string) *string { return &s }
+
+func documents() []document {
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 13, 30, 0, 0, time.UTC) }
+
+	return []document{
+		{
+			base:   base{ID: 1},
+			Number: "A-1",
+			Date:   day(1),
+			Paid:   timestamppb.New(day(2)),
+			Sum:    money.MustParse("100.00"),
+			Status: strptr("new"),
+			Bank:   &bank{BIK: "044525225"},
+		},
+		{
+			base:   base{ID: 2},
+			Number: "b-2",
+			Date:   day(3),
+			Sum:    money.MustParse("2500.50"),
+			Status: strptr("paid"),
+		},
+		{
+			base:   base{ID: 3},
+			Number: "a-3",
+			Date:   day(3),
+			Paid:   timestamppb.New(day(4)),
+			Sum:    money.MustParse("-5.00"),
+			Bank:   &bank{BIK: "044525974"},
+		},
+	}
+}
+
+func ids(items []document) []uint64 {
+	res := make([]uint64, len(items))
+	for i, d := range items {
+		res[i] = d.ID
+	}
+
+	return res
+}
+
+func TestApply(t *testing.T) {
+	get := Tags[document]("json")
+
+	cases := []struct {
+		name   string
+		filter string
+		sort   filtering.SortModel
+		want   []uint64
+	}{
+		{"all", `{}`, nil, []uint64{1, 2, 3}},
+		{
+			"text ignores case",
+			`{"number": {"filterType": "text", "type": "startsWith", "filter": "a"}}`,
+			nil,
+			[]uint64{1, 3},
+		},
+		{
+			"date by day",
+			`{"date": {"filterType": "date", "type": "equals", "dateFrom": "2025-05-03 00:00:00"}}`,
+			nil,
+			[]uint64{2, 3},
+		},
+		{
+			"timestamp",
+			`{"paid": {"filterType": "date", "type": "greaterThan", "dateFrom": "2025-05-02"}}`,
+			nil,
+			[]uint64{3},
+		},
+		{
+			"money",
+			`{"sum": {"filterType": "number", "type": "lessThan", "filter": 1000}}`,
+			nil,
+			[]uint64{1, 3},
+		},
+		{
+			"set with null",
+			`{"status": {"filterType": "set", "values": ["paid", null]}}`,
+			nil,
+			[]uint64{2, 3},
+		},
+		{
+			"null is not equal",
+			`{"status": {"filterType": "text", "type": "notEqual", "filter": "new"}}`,
+			nil,
+			[]uint64{2},
+		},
+		{
+			"nested",
+			`{"bank.bik": {"filterType": "text", "type": "endsWith", "filter": "974"}}`,
+			nil,
+			[]uint64{3},
+		},
+		{
+			"conditions",
+			`{"id": {"filterType": "number", "operator": "OR", "conditions": [
+				{"type": "equals", "filter": 1}, {"type": "equals", "filter": 3}]}}`,
+			filtering.SortModel{{"colId": "id", "sort": "desc"}},
+			[]uint64{3, 1},
+		},
+		{
+			"sort nulls last",
+			`{}`,
+			filtering.SortModel{{"colId": "status", "sort": "asc"}},
+			[]uint64{1, 2, 3},
+		},
+		{
+			"sort nulls first",
+			`{}`,
+			filtering.SortModel{{"colId": "paid", "sort": "desc"}},
+			[]uint64{2, 3, 1},
+		},
+		{
+			"sort stable",
+			`{}`,
+			filtering.SortModel{{"colId": "date", "sort": "desc"}, {"colId": "sum", "sort": "asc"}},
+			[]uint64{3, 2, 1},
+		},
+	}
+
+	for _, c := range cases {
+		fm, err := filtering.ParseJSONToFilterModel(c.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		items := documents()
+
+		got, err := Apply(items, fm, c.sort, get)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+
+			continue
+		}
+
+		if g := ids(got); !equal(g, c.want) {
+			t.Errorf("%s: got %v; want %v", c.name, g, c.want)
+		}
+
+		if g := ids(items); !equal(g, []uint64{1, 2, 3}) {
+			t.Errorf("%s: items changed to %v", c.name, g)
+		}
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	get := Tags[*document]("json")
+	items := []*document{{Number: "1"}}
+
+	_, err := Apply(items, filtering.FilterModel{
+		"Comment": {FilterType: strptr("text"), Type: strptr("equals"), Filter: new(any)},
+	}, nil, get)
+	if !errors.Is(err, filtering.ErrColumn) {
+		t.Errorf("filter: got %v", err)
+	}
+
+	_, err = Apply(items, nil, filtering.SortModel{{"colId": "secret", "sort": "asc"}}, get)
+	if !errors.Is(err, filtering.ErrColumn) {
+		t.Errorf("sort column: got %v", err)
+	}
+
+	_, err = Apply(items, nil, filtering.SortModel{{"colId": "number", "sort": "up"}}, get)
+	if !errors.Is(err, filtering.ErrSort) {
+		t.Errorf("sort: got %v", err)
+	}
+}
+
+func equal(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
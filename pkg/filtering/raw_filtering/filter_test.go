@@ -14,112 +14,155 @@ func any2ptr[T any](s T) *any {
 	return &v
 }
 
-func TestSetWhere(t *testing.T) {
-	cases := []struct {
-		name   string
-		vals   []*string
-		expect string
-	}{
-		{"only values", []*string{strptr("a"), strptr("b")}, "f in ('a','b')"},
-		{"with null", []*string{nil, strptr("x")}, "f in ('x') OR f IS NULL"},
-		{"only null", []*string{nil, strptr("")}, "f IS NULL"},
-		{"empty slice", []*string{}, ""},
-	}
-	for _, c := range cases {
-		got := setWhere("f", c.vals)
-		if got != c.expect {
-			t.Errorf("%s: got %q; want %q", c.name, got, c.expect)
-		}
-	}
-}
-
-func TestDateWhere(t *testing.T) {
+func TestCreateFilter(t *testing.T) {
 	df := strptr("2025-05-23T00:00:00Z")
 	dt := strptr("2025-05-30T23:59:59Z")
 
 	cases := []struct {
 		name string
 		f    filtering.Filter
-		want string
+		want []string
 	}{
 		{
-			"single equals",
-			filtering.Filter{Type: strptr("equals"), DateFrom: df},
-			"DATE(f) = '2025-05-23'",
+			"set",
+			filtering.Filter{
+				FilterType: strptr("set"),
+				Values:     []*string{strptr("a"), strptr("b")},
+			},
+			[]string{"pre.f IN ('a', 'b')"},
 		},
 		{
-			"inRange",
-			filtering.Filter{Type: strptr("inRange"), DateFrom: df, DateTo: dt},
-			"DATE(f) between '2025-05-23' AND '2025-05-30'",
+			"set with null",
+			filtering.Filter{FilterType: strptr("set"), Values: []*string{nil, strptr("x")}},
+			[]string{"(pre.f IN ('x') OR pre.f IS NULL)"},
 		},
-	}
-	for _, c := range cases {
-		if got := dateWhere("f", c.f); got != c.want {
-			t.Errorf("%s: got %q; want %q", c.name, got, c.want)
-		}
-	}
-}
-
-func TestNumberWhere(t *testing.T) {
-	n := 42
-	n2 := "100"
-
-	cases := []struct {
-		name string
-		f    filtering.Filter
-		want string
-	}{
-		{"equals", filtering.Filter{Type: strptr("equals"), Filter: any2ptr(n)}, "f = 42"},
 		{
-			"inRange",
-			filtering.Filter{Type: strptr("inRange"), Filter: any2ptr(n), FilterTo: any2ptr(n2)},
-			"f between 42 AND 100",
+			"set only null",
+			filtering.Filter{FilterType: strptr("set"), Values: []*string{nil, strptr("")}},
+			[]string{"pre.f IS NULL"},
+		},
+		{
+			"date equals",
+			filtering.Filter{FilterType: strptr("date"), Type: strptr("equals"), DateFrom: df},
+			[]string{"DATE(pre.f) = '2025-05-23'"},
+		},
+		{
+			"date inRange",
+			filtering.Filter{
+				FilterType: strptr("date"),
+				Type:       strptr("inRange"),
+				DateFrom:   df,
+				DateTo:     dt,
+			},
+			[]string{"DATE(pre.f) BETWEEN '2025-05-23' AND '2025-05-30'"},
+		},
+		{
+			"number equals",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Type:       strptr("equals"),
+				Filter:     any2ptr(42.5),
+			},
+			[]string{"pre.f = 42.5"},
+		},
+		{
+			"number inRange",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Type:       strptr("inRange"),
+				Filter:     any2ptr(42),
+				FilterTo:   any2ptr("100"),
+			},
+			[]string{"pre.f BETWEEN 42 AND '100'"},
+		},
+		{
+			"text equals",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Type:       strptr("equals"),
+				Filter:     any2ptr("AbC"),
+			},
+			[]string{"lower(pre.f) = 'abc'"},
+		},
+		{
+			// кавычка экранируется, подстановочные символы LIKE как в CreateParamFilter
+			"text contains",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Type:       strptr("contains"),
+				Filter:     any2ptr("O'Brien 50%"),
+			},
+			[]string{`lower(pre.f) LIKE '%o''brien 50\%%'`},
+		},
+		{
+			"text startsWith",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Type:       strptr("startsWith"),
+				Filter:     any2ptr("Hey"),
+			},
+			[]string{"lower(pre.f) LIKE 'hey%'"},
+		},
+		{
+			"text endsWith",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Type:       strptr("endsWith"),
+				Filter:     any2ptr("Lo"),
+			},
+			[]string{"lower(pre.f) LIKE '%lo'"},
+		},
+		{
+			"conditions",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Operator:   strptr("OR"),
+				Condition1: cond(filtering.Filter{Type: strptr("lessThan"), Filter: any2ptr(1)}),
+				Condition2: cond(filtering.Filter{Type: strptr("greaterThan"), Filter: any2ptr(9)}),
+			},
+			[]string{"(pre.f < 1 OR pre.f > 9)"},
+		},
+		{
+			"search skipped",
+			filtering.Filter{FilterType: strptr("search"), Filter: any2ptr("связь")},
+			[]string{},
+		},
+		{
+			"invalid filter",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Type:       strptr("equals"),
+				Filter:     any2ptr("x"),
+			},
+			[]string{},
 		},
 	}
-	for _, c := range cases {
-		if got := numberWhere("f", c.f); got != c.want {
-			t.Errorf("%s: got %q; want %q", c.name, got, c.want)
-		}
-	}
-}
 
-func TestTextWhere(t *testing.T) {
-	cases := []struct {
-		name string
-		typ  string
-		val  string
-		want string
-	}{
-		{"equals", "equals", "AbC", "lower(f) = 'abc'"},
-		{"contains", "contains", "Xy", "lower(f) like '%xy%'"},
-		{"startsWith", "startsWith", "Hey", "lower(f) like 'hey%'"},
-		{"endsWith", "endsWith", "Lo", "lower(f) like '%lo'"},
-	}
 	for _, c := range cases {
-		val := any(c.val)
-
-		f := filtering.Filter{Type: strptr(c.typ), Filter: &val}
-		if got := textWhere("f", f); got != c.want {
+		got := CreateFilter(filtering.FilterModel{"f": c.f}, strptr("pre"))
+		if !reflect.DeepEqual(got, c.want) {
 			t.Errorf("%s: got %q; want %q", c.name, got, c.want)
 		}
 	}
-}
 
-func TestCreateFilter(t *testing.T) {
-	m := filtering.FilterModel{
-		"a": {FilterType: strptr("set"), Values: []*string{strptr("x")}},
+	// поля сортируются, как в CreateParamFilter
+	got := CreateFilter(filtering.FilterModel{
 		"b": {FilterType: strptr("text"), Type: strptr("contains"), Filter: any2ptr("Z")},
-	}
-	out := CreateFilter(m, strptr("pre"))
-	want1 := "pre.a in ('x')"
-	want2 := "lower(pre.b) like '%z%'"
-
-	if len(out) != 2 || (out[0] != want1 && out[1] != want1) {
-		t.Errorf("CreateFilter missing set; got %v", out)
+		"a": {FilterType: strptr("set"), Values: []*string{strptr("x")}},
+	}, strptr("pre"))
+	if want := []string{"pre.a IN ('x')", "lower(pre.b) LIKE '%z%'"}; !reflect.DeepEqual(
+		got,
+		want,
+	) {
+		t.Errorf("got %q; want %q", got, want)
 	}
 
-	if len(out) != 2 || (out[0] != want2 && out[1] != want2) {
-		t.Errorf("CreateFilter missing text; got %v", out)
+	// поле не идентификатор: модель отклоняется целиком
+	got = CreateFilter(filtering.FilterModel{
+		"a = 1 or 1": {FilterType: strptr("set"), Values: []*string{strptr("x")}},
+	}, nil)
+	if len(got) != 0 {
+		t.Errorf("injected field: got %q", got)
 	}
 }
 
@@ -164,6 +207,8 @@ func TestSortModel_CreateOrder(t *testing.T) {
 		{"colId": "name", "sort": "ASC"},
 		{"colId": "owner.field", "sort": "DESC"},
 		{"colId": "age", "sort": "desc"},
+		{"colId": "id", "sort": "desc; drop table users"},
+		{"colId": "id; drop table x --", "sort": "asc"},
 	}
 
 	cases := []struct {
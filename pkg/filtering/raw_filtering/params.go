@@ -0,0 +1,296 @@
+package raw_filtering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+)
+
+var (
+	ErrField      = ast.ErrField
+	ErrFilterType = ast.ErrFilterType
+	ErrType       = ast.ErrType
+	ErrOperator   = ast.ErrOperator
+	ErrValue      = ast.ErrValue
+)
+
+// Placeholder is the bind variable style of the driver.
+type Placeholder int
+
+const (
+	// Dollar numbers placeholders $1, $2…, used by pgx and lib/pq.
+	Dollar Placeholder = iota
+	// Question uses ? placeholders, used by sqlx with MySQL and SQLite and by squirrel.
+	Question
+	// Inline puts values into SQL as quoted literals, used only by the deprecated CreateFilter.
+	Inline
+)
+
+// Params collects the arguments of a parameterised query.
+// Args added before building a filter keep their positions, so the filter
+// can be appended to a query that already has arguments.
+type Params struct {
+	Placeholder Placeholder
+	Args        []any
+}
+
+// Add appends the value to the arguments and returns its placeholder,
+// Inline returns the value itself.
+func (p *Params) Add(v any) string {
+	if p.Placeholder == Inline {
+		return literal(v)
+	}
+
+	p.Args = append(p.Args, v)
+
+	if p.Placeholder == Question {
+		return "?"
+	}
+
+	return "$" + strconv.Itoa(len(p.Args))
+}
+
+// CreateParamFilter builds SQL conditions of the filter model, values go to params.
+// Conditions must be joined with AND, fields are processed in sorted order,
+// so the same model always gives the same query.
+func CreateParamFilter(
+	fm filtering.FilterModel,
+	prefix *string,
+	params *Params,
+) ([]string, error) {
+	tree, err := ast.Parse(fm, safeDeref(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	return renderAll(tree, params), nil
+}
+
+// CreateSchemaFilter builds SQL conditions of the filter model on the columns of the schema.
+func CreateSchemaFilter(
+	fm filtering.FilterModel,
+	schema *filtering.Schema,
+	params *Params,
+) ([]string, error) {
+	tree, err := ast.ParseSchema(fm, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderAll(tree, params), nil
+}
+
+// Where builds the condition of a filter on the field or SQL expression, values go to params.
+// The two-condition form is joined by its operator, an empty set gives an empty condition.
+func Where(field string, f filtering.Filter, params *Params) (string, error) {
+	node, err := ast.ParseFilter(field, field, f)
+	if err != nil {
+		return "", err
+	}
+
+	return Render(node, params), nil
+}
+
+// CreateSchemaOrder builds SQL ORDER BY clauses of the sort model on the columns of the schema.
+func CreateSchemaOrder(sm *filtering.SortModel, schema *filtering.Schema) ([]string, error) {
+	if sm == nil {
+		return []string{}, nil
+	}
+
+	orders, err := schema.Orders(*sm)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]string, len(orders))
+	for i, o := range orders {
+		out[i] = o.String()
+	}
+
+	return out, nil
+}
+
+// renderAll renders the nodes of the group separately, skipping empty ones.
+func renderAll(g *ast.Group, params *Params) []string {
+	out := make([]string, 0, len(g.Nodes))
+
+	for _, node := range g.Nodes {
+		if where := Render(node, params); where != "" {
+			out = append(out, where)
+		}
+	}
+
+	return out
+}
+
+// ── rendering of the filter tree ──────────────────────────────────────
+
+// Render builds the SQL condition of the node, values go to params.
+// An empty group gives an empty condition, groups of several nodes are parenthesised.
+func Render(node ast.Node, params *Params) string {
+	switch n := node.(type) {
+	case *ast.Group:
+		return renderGroup(n, params)
+	case *ast.Cond:
+		return renderCond(n, params)
+	default:
+		return ""
+	}
+}
+
+func renderGroup(g *ast.Group, params *Params) string {
+	parts := make([]string, 0, len(g.Nodes))
+
+	for _, node := range g.Nodes {
+		if where := Render(node, params); where != "" {
+			parts = append(parts, where)
+		}
+	}
+
+	if len(parts) <= 1 {
+		return strings.Join(parts, "")
+	}
+
+	return "(" + strings.Join(parts, " "+string(g.Logic)+" ") + ")"
+}
+
+var compareOps = map[ast.Op]string{
+	ast.Equals:             "=",
+	ast.NotEqual:           "<>",
+	ast.GreaterThan:        ">",
+	ast.GreaterThanOrEqual: ">=",
+	ast.LessThan:           "<",
+	ast.LessThanOrEqual:    "<=",
+}
+
+// likeEscaper escapes wildcards of LIKE, so % and _ typed by a user match themselves.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func renderCond(c *ast.Cond, params *Params) string {
+	field := c.Field
+
+	switch c.Kind {
+	case ast.Set:
+		return renderSet(c, params)
+	case ast.Search:
+		return renderSearch(c, params)
+	case ast.Text:
+		field = "lower(" + field + ")"
+	case ast.Date:
+		field = "DATE(" + field + ")"
+	}
+
+	switch c.Op {
+	case ast.Blank:
+		if c.Kind == ast.Text {
+			return fmt.Sprintf("(%s IS NULL OR %s = '')", c.Field, c.Field)
+		}
+
+		return c.Field + " IS NULL"
+	case ast.NotBlank:
+		if c.Kind == ast.Text {
+			return fmt.Sprintf("(%s IS NOT NULL AND %s <> '')", c.Field, c.Field)
+		}
+
+		return c.Field + " IS NOT NULL"
+	case ast.InRange:
+		return fmt.Sprintf(
+			"%s BETWEEN %s AND %s",
+			field,
+			params.Add(c.Values[0]),
+			params.Add(c.Values[1]),
+		)
+	case ast.Contains, ast.NotContains, ast.StartsWith, ast.EndsWith:
+		return renderLike(field, c, params)
+	}
+
+	val := c.Values[0]
+	if c.Kind == ast.Text {
+		val = strings.ToLower(fmt.Sprint(val))
+	}
+
+	return fmt.Sprintf("%s %s %s", field, compareOps[c.Op], params.Add(val))
+}
+
+func renderLike(field string, c *ast.Cond, params *Params) string {
+	pattern := likeEscaper.Replace(strings.ToLower(fmt.Sprint(c.Values[0])))
+
+	switch c.Op {
+	case ast.StartsWith:
+		pattern += "%"
+	case ast.EndsWith:
+		pattern = "%" + pattern
+	default:
+		pattern = "%" + pattern + "%"
+	}
+
+	if c.Op == ast.NotContains {
+		return fmt.Sprintf("%s NOT LIKE %s", field, params.Add(pattern))
+	}
+
+	return fmt.Sprintf("%s LIKE %s", field, params.Add(pattern))
+}
+
+// renderSearch uses the GIN indexes of the column: on to_tsvector(SearchConfig, column)
+// for full-text search and gin_trgm_ops for Similar.
+func renderSearch(c *ast.Cond, params *Params) string {
+	if c.Op == ast.Similar {
+		return params.Add(c.Values[0]) + " <% " + c.Field
+	}
+
+	return tsvector(c.Field) + " @@ " + tsquery(params.Add(c.Values[0]))
+}
+
+// Rank builds ORDER BY clauses ranking rows by the search conditions of the node,
+// the best matches first. It is empty when the node has no search.
+func Rank(node ast.Node, params *Params) []string {
+	searches := ast.Searches(node)
+	out := make([]string, len(searches))
+
+	for i, c := range searches {
+		if c.Op == ast.Similar {
+			out[i] = fmt.Sprintf("word_similarity(%s, %s) DESC", params.Add(c.Values[0]), c.Field)
+		} else {
+			out[i] = fmt.Sprintf(
+				"ts_rank(%s, %s) DESC",
+				tsvector(c.Field),
+				tsquery(params.Add(c.Values[0])),
+			)
+		}
+	}
+
+	return out
+}
+
+func tsvector(field string) string {
+	return "to_tsvector('" + ast.SearchConfig + "', " + field + ")"
+}
+
+func tsquery(value string) string {
+	return "websearch_to_tsquery('" + ast.SearchConfig + "', " + value + ")"
+}
+
+func renderSet(c *ast.Cond, params *Params) string {
+	if len(c.Values) == 0 {
+		if c.Null {
+			return c.Field + " IS NULL"
+		}
+
+		return ""
+	}
+
+	in := make([]string, len(c.Values))
+	for i, v := range c.Values {
+		in[i] = params.Add(v)
+	}
+
+	joined := strings.Join(in, ", ")
+	if c.Null {
+		return fmt.Sprintf("(%s IN (%s) OR %s IS NULL)", c.Field, joined, c.Field)
+	}
+
+	return fmt.Sprintf("%s IN (%s)", c.Field, joined)
+}
@@ -0,0 +1,373 @@
+package raw_filtering
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+)
+
+func cond(f filtering.Filter) *filtering.Condition {
+	return &filtering.Condition{Filter: f}
+}
+
+func TestCreateParamFilter(t *testing.T) {
+	df := strptr("2025-05-23 00:00:00")
+	dt := strptr("2025-05-30 00:00:00")
+
+	cases := []struct {
+		name  string
+		f     filtering.Filter
+		ph    Placeholder
+		where string
+		args  []any
+	}{
+		{
+			"set",
+			filtering.Filter{
+				FilterType: strptr("set"),
+				Values:     []*string{strptr("a"), strptr("b'c")},
+			},
+			Dollar,
+			"f IN ($1, $2)",
+			[]any{"a", "b'c"},
+		},
+		{
+			"set with null",
+			filtering.Filter{FilterType: strptr("set"), Values: []*string{nil, strptr("x")}},
+			Question,
+			"(f IN (?) OR f IS NULL)",
+			[]any{"x"},
+		},
+		{
+			"set only null",
+			filtering.Filter{FilterType: strptr("set"), Values: []*string{strptr("")}},
+			Dollar,
+			"f IS NULL",
+			nil,
+		},
+		{
+			"text quote",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Type:       strptr("equals"),
+				Filter:     any2ptr("O'Brien"),
+			},
+			Dollar,
+			"lower(f) = $1",
+			[]any{"o'brien"},
+		},
+		{
+			"text contains escapes wildcards",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Type:       strptr("contains"),
+				Filter:     any2ptr("10%_A"),
+			},
+			Dollar,
+			"lower(f) LIKE $1",
+			[]any{`%10\%\_a%`},
+		},
+		{
+			"number inRange",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Type:       strptr("inRange"),
+				Filter:     any2ptr(1.5),
+				FilterTo:   any2ptr(10),
+			},
+			Question,
+			"f BETWEEN ? AND ?",
+			[]any{1.5, 10},
+		},
+		{
+			"date",
+			filtering.Filter{FilterType: strptr("date"), Type: strptr("lessThan"), DateFrom: df},
+			Dollar,
+			"DATE(f) < $1",
+			[]any{"2025-05-23"},
+		},
+		{
+			"date operator",
+			filtering.Filter{
+				FilterType: strptr("date"),
+				Operator:   strptr("or"),
+				Condition1: cond(filtering.Filter{
+					Type:     strptr("inRange"),
+					DateFrom: df,
+					DateTo:   dt,
+				}),
+				Condition2: cond(filtering.Filter{Type: strptr("equals"), DateFrom: dt}),
+			},
+			Dollar,
+			"(DATE(f) BETWEEN $1 AND $2 OR DATE(f) = $3)",
+			[]any{"2025-05-23", "2025-05-30", "2025-05-30"},
+		},
+		{
+			"text operator",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Operator:   strptr("AND"),
+				Condition1: cond(
+					filtering.Filter{Type: strptr("startsWith"), Filter: any2ptr("A")},
+				),
+				Condition2: cond(
+					filtering.Filter{Type: strptr("notContains"), Filter: any2ptr("b")},
+				),
+			},
+			Question,
+			"(lower(f) LIKE ? AND lower(f) NOT LIKE ?)",
+			[]any{"a%", "%b%"},
+		},
+		{
+			"search",
+			filtering.Filter{FilterType: strptr("search"), Filter: any2ptr("оплата -аренда")},
+			Dollar,
+			"to_tsvector('russian', f) @@ websearch_to_tsquery('russian', $1)",
+			[]any{"оплата -аренда"},
+		},
+		{
+			"search similar",
+			filtering.Filter{
+				FilterType: strptr("search"),
+				Type:       strptr("similar"),
+				Filter:     any2ptr("догвор"),
+			},
+			Question,
+			"? <% f",
+			[]any{"догвор"},
+		},
+		{
+			"number operator",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Operator:   strptr("OR"),
+				Condition1: cond(filtering.Filter{Type: strptr("lessThan"), Filter: any2ptr(1)}),
+				Condition2: cond(filtering.Filter{Type: strptr("greaterThan"), Filter: any2ptr(9)}),
+			},
+			Dollar,
+			"(f < $1 OR f > $2)",
+			[]any{1, 9},
+		},
+	}
+
+	for _, c := range cases {
+		params := &Params{Placeholder: c.ph}
+
+		got, err := CreateParamFilter(filtering.FilterModel{"f": c.f}, nil, params)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+
+			continue
+		}
+
+		if len(got) != 1 || got[0] != c.where {
+			t.Errorf("%s: got %q; want %q", c.name, got, c.where)
+		}
+
+		if !reflect.DeepEqual(params.Args, c.args) {
+			t.Errorf("%s: args %#v; want %#v", c.name, params.Args, c.args)
+		}
+	}
+}
+
+func TestCreateParamFilterModel(t *testing.T) {
+	m := filtering.FilterModel{
+		"b":       {FilterType: strptr("text"), Type: strptr("contains"), Filter: any2ptr("Z")},
+		"a":       {FilterType: strptr("set"), Values: []*string{strptr("x")}},
+		"owner.c": {FilterType: strptr("number"), Type: strptr("equals"), Filter: any2ptr(3)},
+		"empty":   {},
+	}
+
+	// аргументы запроса до фильтра сохраняют свои номера
+	params := &Params{Args: []any{7}}
+
+	got, err := CreateParamFilter(m, strptr("pre"), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"pre.a IN ($2)", "lower(pre.b) LIKE $3", "owner.c = $4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if args := []any{7, "x", "%z%", 3}; !reflect.DeepEqual(params.Args, args) {
+		t.Errorf("args %#v; want %#v", params.Args, args)
+	}
+}
+
+func TestCreateParamFilterErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		field string
+		f     filtering.Filter
+		err   error
+	}{
+		{
+			"injection in field",
+			"a = a; drop table users; --",
+			filtering.Filter{FilterType: strptr("set"), Values: []*string{strptr("x")}},
+			ErrField,
+		},
+		{"unknown filterType", "f", filtering.Filter{FilterType: strptr("geo")}, ErrFilterType},
+		{
+			"unknown type",
+			"f",
+			filtering.Filter{
+				FilterType: strptr("number"),
+				Type:       strptr("like"),
+				Filter:     any2ptr(1),
+			},
+			ErrType,
+		},
+		{
+			"operator",
+			"f",
+			filtering.Filter{
+				FilterType: strptr("text"),
+				Operator:   strptr("XOR"),
+				Condition1: cond(filtering.Filter{Type: strptr("equals"), Filter: any2ptr("a")}),
+				Condition2: cond(filtering.Filter{Type: strptr("equals"), Filter: any2ptr("b")}),
+			},
+			ErrOperator,
+		},
+		{
+			"missing condition",
+			"f",
+			filtering.Filter{FilterType: strptr("text"), Operator: strptr("OR")},
+			ErrValue,
+		},
+		{
+			"missing dateTo",
+			"f",
+			filtering.Filter{
+				FilterType: strptr("date"),
+				Type:       strptr("inRange"),
+				DateFrom:   strptr("2025-05-23"),
+			},
+			ErrValue,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := CreateParamFilter(filtering.FilterModel{c.field: c.f}, nil, &Params{})
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: got %v; want %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestCreateSchemaFilter(t *testing.T) {
+	schema := &filtering.Schema{
+		Columns: map[string]filtering.Column{
+			"name": {Expr: "coalesce(d.name, c.name)", FilterType: "text"},
+			"sum":  {Expr: "pd.sum", FilterType: "number"},
+		},
+	}
+	m := filtering.FilterModel{
+		"name": {FilterType: strptr("text"), Type: strptr("startsWith"), Filter: any2ptr("A")},
+		"sum":  {Type: strptr("greaterThan"), Filter: any2ptr(100)},
+	}
+	params := &Params{}
+
+	got, err := CreateSchemaFilter(m, schema, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"lower(coalesce(d.name, c.name)) LIKE $1", "pd.sum > $2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if _, err := CreateSchemaFilter(
+		filtering.FilterModel{"secret": {FilterType: strptr("text")}},
+		schema,
+		&Params{},
+	); !errors.Is(err, filtering.ErrColumn) {
+		t.Errorf("unknown column: got %v", err)
+	}
+
+	order, err := CreateSchemaOrder(
+		&filtering.SortModel{{"colId": "sum", "sort": "desc"}, {"colId": "name", "sort": "asc"}},
+		schema,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"pd.sum DESC", "coalesce(d.name, c.name) ASC"}; !reflect.DeepEqual(
+		order,
+		want,
+	) {
+		t.Errorf("order %q; want %q", order, want)
+	}
+}
+
+func TestCreateParamFilterConditions(t *testing.T) {
+	m, err := filtering.ParseJSONToFilterModel(`{"name": {
+		"filterType": "text", "operator": "OR",
+		"conditions": [
+			{"type": "startsWith", "filter": "a"},
+			{"type": "endsWith", "filter": "b"},
+			{"type": "blank"}
+		]
+	}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := &Params{}
+
+	got, err := CreateParamFilter(m, nil, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"(lower(name) LIKE $1 OR lower(name) LIKE $2 OR (name IS NULL OR name = ''))"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if args := []any{"a%", "%b"}; !reflect.DeepEqual(params.Args, args) {
+		t.Errorf("args %#v; want %#v", params.Args, args)
+	}
+}
+
+func TestRank(t *testing.T) {
+	fm := filtering.FilterModel{
+		"purpose": {FilterType: strptr("search"), Filter: any2ptr("связь")},
+		"payer": {
+			FilterType: strptr("search"),
+			Type:       strptr("similar"),
+			Filter:     any2ptr("ромашка"),
+		},
+		"name": {FilterType: strptr("text"), Type: strptr("contains"), Filter: any2ptr("x")},
+	}
+
+	tree, err := ast.Parse(fm, "pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := &Params{Args: []any{1}}
+	want := []string{
+		"word_similarity($2, pd.payer) DESC",
+		"ts_rank(to_tsvector('russian', pd.purpose), websearch_to_tsquery('russian', $3)) DESC",
+	}
+
+	if got := Rank(tree, params); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(params.Args, []any{1, "ромашка", "связь"}) {
+		t.Errorf("args %#v", params.Args)
+	}
+
+	if got := Rank(&ast.Group{}, params); len(got) != 0 {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,211 @@
+package filtering
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrColumn     = errors.New("unknown column")
+	ErrFilterType = errors.New("filterType is not allowed for the column")
+	ErrOperator   = errors.New("filter type is not allowed for the column")
+	ErrSort       = errors.New("sort must be asc or desc")
+)
+
+// fieldPattern is a column, optionally qualified by a table alias.
+var fieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Unknown tells the schema what to do with unknown columns and invalid sort directions.
+type Unknown int
+
+const (
+	// Reject returns an error.
+	Reject Unknown = iota
+	// Ignore drops the filter or the sort.
+	Ignore
+)
+
+// Column describes a grid column.
+type Column struct {
+	// Expr is the SQL expression of the column, e.g. "pd.date" or "coalesce(d.name, c.name)",
+	// the column ID is used when empty. Expressions come from code and are not checked.
+	Expr string
+	// FilterType is the allowed filterType: text, number, date, set or search,
+	// empty means not filterable.
+	FilterType string
+	// Operators are the allowed filter types, e.g. equals or contains, empty allows all.
+	Operators []string
+}
+
+// Schema maps grid column IDs to SQL expressions, only its columns can be filtered and sorted.
+type Schema struct {
+	Columns map[string]Column
+	Unknown Unknown
+}
+
+// Field is a filter of the model resolved by the schema.
+type Field struct {
+	Column string
+	Expr   string
+	Filter Filter
+}
+
+// Order is a sort of the model resolved by the schema.
+type Order struct {
+	Expr string
+	Desc bool
+}
+
+func (o Order) String() string {
+	if o.Desc {
+		return o.Expr + " DESC"
+	}
+
+	return o.Expr + " ASC"
+}
+
+// Fields checks the filter model and returns its filters sorted by column,
+// filterType of a filter is set to the column one when empty.
+func (s *Schema) Fields(fm FilterModel) ([]Field, error) {
+	columns := make([]string, 0, len(fm))
+	for column := range fm {
+		columns = append(columns, column)
+	}
+
+	slices.Sort(columns)
+
+	res := make([]Field, 0, len(fm))
+
+	for _, id := range columns {
+		f := fm[id]
+		if f.IsEmpty() {
+			continue
+		}
+
+		column, ok := s.Columns[id]
+		if !ok || column.FilterType == "" {
+			if s.Unknown == Ignore {
+				continue
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("%w %q", ErrColumn, id)
+			}
+
+			return nil, fmt.Errorf("%s: %w", id, ErrFilterType)
+		}
+
+		if err := column.check(&f); err != nil {
+			if s.Unknown == Ignore {
+				continue
+			}
+
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+
+		res = append(res, Field{Column: id, Expr: column.expr(id), Filter: f})
+	}
+
+	return res, nil
+}
+
+// Orders checks the sort model and returns its sorts in order.
+func (s *Schema) Orders(sm SortModel) ([]Order, error) {
+	res := make([]Order, 0, len(sm))
+
+	for _, sort := range sm {
+		id := sort["colId"]
+
+		column, ok := s.Columns[id]
+		if !ok {
+			if s.Unknown == Ignore {
+				continue
+			}
+
+			return nil, fmt.Errorf("%w %q", ErrColumn, id)
+		}
+
+		desc, ok := SortDesc(sort["sort"])
+		if !ok {
+			if s.Unknown == Ignore {
+				continue
+			}
+
+			return nil, fmt.Errorf("%s: %w, got %q", id, ErrSort, sort["sort"])
+		}
+
+		res = append(res, Order{Expr: column.expr(id), Desc: desc})
+	}
+
+	return res, nil
+}
+
+// Expr returns the SQL expression of the column.
+func (s *Schema) Expr(id string) (string, error) {
+	column, ok := s.Columns[id]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrColumn, id)
+	}
+
+	return column.expr(id), nil
+}
+
+// ValidField reports whether the field is an identifier optionally qualified by a table alias,
+// so it may go into SQL as is.
+func ValidField(field string) bool {
+	return fieldPattern.MatchString(field)
+}
+
+// SortDesc parses a sort direction, ok is false for anything but asc and desc.
+func SortDesc(sort string) (desc, ok bool) {
+	switch strings.ToLower(sort) {
+	case "asc":
+		return false, true
+	case "desc":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func (c Column) expr(id string) string {
+	if c.Expr == "" {
+		return id
+	}
+
+	return c.Expr
+}
+
+// check checks the filter types of the filter and its conditions.
+func (c Column) check(f *Filter) error {
+	if f.FilterType == nil {
+		f.FilterType = &c.FilterType
+	}
+
+	return c.checkTypes(f)
+}
+
+func (c Column) checkTypes(f *Filter) error {
+	if f.FilterType != nil && *f.FilterType != c.FilterType {
+		return fmt.Errorf("%w: %q", ErrFilterType, *f.FilterType)
+	}
+
+	if f.Type != nil && len(c.Operators) > 0 && !slices.Contains(c.Operators, *f.Type) {
+		return fmt.Errorf("%w: %q", ErrOperator, *f.Type)
+	}
+
+	for _, cond := range f.Combined() {
+		if cond == nil {
+			continue
+		}
+
+		if err := c.checkTypes(&cond.Filter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
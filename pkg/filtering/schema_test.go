@@ -0,0 +1,148 @@
+package filtering
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func strptr(s string) *string { return &s }
+
+var schema = &Schema{
+	Columns: map[string]Column{
+		"date": {Expr: "pd.date", FilterType: "date"},
+		"name": {
+			Expr:       "coalesce(d.name, c.name)",
+			FilterType: "text",
+			Operators:  []string{"contains"},
+		},
+		"status": {FilterType: "set"},
+		"id":     {},
+	},
+}
+
+func TestSchemaFields(t *testing.T) {
+	fm := FilterModel{
+		"status": {Values: []*string{strptr("new")}},
+		"name":   {FilterType: strptr("text"), Type: strptr("contains")},
+		"date": {
+			FilterType: strptr("date"),
+			Type:       strptr("equals"),
+			DateFrom:   strptr("2025-05-23"),
+		},
+	}
+
+	fields, err := schema.Fields(fm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, len(fields))
+	for i, f := range fields {
+		got[i] = f.Column + ":" + f.Expr + ":" + *f.Filter.FilterType
+	}
+
+	want := []string{
+		"date:pd.date:date",
+		"name:coalesce(d.name, c.name):text",
+		"status:status:set",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestSchemaFieldsErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		fm   FilterModel
+		err  error
+	}{
+		{"unknown column", FilterModel{"1=1; --": {FilterType: strptr("text")}}, ErrColumn},
+		{"not filterable", FilterModel{"id": {FilterType: strptr("number")}}, ErrFilterType},
+		{"filterType", FilterModel{"date": {FilterType: strptr("text")}}, ErrFilterType},
+		{
+			"operator",
+			FilterModel{"name": {FilterType: strptr("text"), Type: strptr("equals")}},
+			ErrOperator,
+		},
+		{
+			"condition operator",
+			FilterModel{"name": {
+				FilterType: strptr("text"),
+				Operator:   strptr("OR"),
+				Condition1: &Condition{Filter{Type: strptr("contains")}},
+				Condition2: &Condition{Filter{Type: strptr("startsWith")}},
+			}},
+			ErrOperator,
+		},
+	}
+
+	for _, c := range cases {
+		if _, err := schema.Fields(c.fm); !errors.Is(err, c.err) {
+			t.Errorf("%s: got %v; want %v", c.name, err, c.err)
+		}
+
+		ignore := *schema
+		ignore.Unknown = Ignore
+
+		if fields, err := ignore.Fields(c.fm); err != nil || len(fields) != 0 {
+			t.Errorf("%s: ignore got %v, %v", c.name, fields, err)
+		}
+	}
+}
+
+func TestSchemaOrders(t *testing.T) {
+	sm := SortModel{
+		{"colId": "date", "sort": "DESC"},
+		{"colId": "id", "sort": "asc"},
+	}
+
+	orders, err := schema.Orders(sm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Order{{Expr: "pd.date", Desc: true}, {Expr: "id"}}
+	if !reflect.DeepEqual(orders, want) {
+		t.Errorf("got %v; want %v", orders, want)
+	}
+
+	bad := SortModel{
+		{"colId": "date", "sort": "desc; drop table users"},
+		{"colId": "password", "sort": "asc"},
+	}
+
+	if _, err := schema.Orders(bad[:1]); !errors.Is(err, ErrSort) {
+		t.Errorf("sort: got %v", err)
+	}
+
+	if _, err := schema.Orders(bad[1:]); !errors.Is(err, ErrColumn) {
+		t.Errorf("column: got %v", err)
+	}
+
+	ignore := *schema
+	ignore.Unknown = Ignore
+
+	if orders, err := ignore.Orders(append(bad, sm...)); err != nil || len(orders) != 2 {
+		t.Errorf("ignore: got %v, %v", orders, err)
+	}
+}
+
+func TestSchemaFieldsConditions(t *testing.T) {
+	fm := FilterModel{"name": {
+		FilterType: strptr("text"),
+		Operator:   strptr("AND"),
+		Conditions: []*Condition{
+			{Filter{Type: strptr("contains")}},
+			{Filter{Operator: strptr("OR"), Conditions: []*Condition{
+				{Filter{Type: strptr("contains")}},
+				{Filter{Type: strptr("equals")}},
+			}}},
+		},
+	}}
+
+	if _, err := schema.Fields(fm); !errors.Is(err, ErrOperator) {
+		t.Errorf("got %v; want %v", err, ErrOperator)
+	}
+}
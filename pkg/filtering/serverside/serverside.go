@@ -0,0 +1,216 @@
+// Package serverside supports the server-side row model of AG Grid: a Request is turned into
+// a backend-neutral Query with the filter, grouping, aggregation and paging of the requested
+// block, squirrel_fltering and gorm_filtering render it.
+package serverside
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+)
+
+var (
+	ErrRange     = errors.New("invalid row range")
+	ErrAggFunc   = errors.New("unknown aggFunc")
+	ErrGroupKeys = errors.New("more group keys than row group columns")
+)
+
+// aggFuncs are the aggregation functions of AG Grid supported in SQL.
+var aggFuncs = map[string]string{
+	"sum":   "SUM",
+	"min":   "MIN",
+	"max":   "MAX",
+	"count": "COUNT",
+	"avg":   "AVG",
+}
+
+// Column is a ColumnVO of the request.
+type Column struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	Field       string `json:"field,omitempty"`
+	AggFunc     string `json:"aggFunc,omitempty"`
+}
+
+// Request is IServerSideGetRowsRequest of AG Grid.
+type Request struct {
+	StartRow     int                   `json:"startRow"`
+	EndRow       int                   `json:"endRow"`
+	RowGroupCols []Column              `json:"rowGroupCols"`
+	ValueCols    []Column              `json:"valueCols"`
+	PivotCols    []Column              `json:"pivotCols"`
+	PivotMode    bool                  `json:"pivotMode"`
+	GroupKeys    []string              `json:"groupKeys"`
+	FilterModel  filtering.FilterModel `json:"filterModel"`
+	SortModel    filtering.SortModel   `json:"sortModel"`
+}
+
+// ParseRequest parses the JSON body of a request.
+func ParseRequest(data []byte) (*Request, error) {
+	var r Request
+
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Query is the query of a block of rows.
+type Query struct {
+	// Select holds the group column and aggregates of group rows aliased by column IDs,
+	// it is empty for leaf rows, whose columns are chosen by the caller.
+	Select []string
+	// Where is the filter model and the group keys of the opened groups.
+	Where   *ast.Group
+	GroupBy []string
+	OrderBy []string
+	// Limit is one row more than the block, so that LastRow can tell whether it is the last one,
+	// zero means no limit.
+	Limit  uint64
+	Offset uint64
+}
+
+// IsGroup reports whether the query selects group rows.
+func (q *Query) IsGroup() bool {
+	return len(q.GroupBy) > 0
+}
+
+// Query builds the query of the request, columns are resolved by the schema.
+// Pivot columns are grouped by together with the row group column,
+// the caller turns their values into pivot result columns.
+func (r *Request) Query(schema *filtering.Schema) (*Query, error) {
+	if r.StartRow < 0 || r.EndRow < 0 || (r.EndRow > 0 && r.EndRow < r.StartRow) {
+		return nil, fmt.Errorf("%w: %d-%d", ErrRange, r.StartRow, r.EndRow)
+	}
+
+	if len(r.GroupKeys) > len(r.RowGroupCols) {
+		return nil, ErrGroupKeys
+	}
+
+	where, err := ast.ParseSchema(r.FilterModel, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{Where: where, Offset: uint64(r.StartRow)} //nolint:gosec // проверено выше
+	if r.EndRow > 0 {
+		q.Limit = uint64(r.EndRow-r.StartRow) + 1 //nolint:gosec // проверено выше
+	}
+
+	// открытые группы ограничивают строки своими ключами
+	for i, key := range r.GroupKeys {
+		expr, err := schema.Expr(r.RowGroupCols[i].ID)
+		if err != nil {
+			return nil, err
+		}
+
+		cond := &ast.Cond{Column: r.RowGroupCols[i].ID, Field: expr, Kind: ast.Set, Op: ast.In}
+		if key == "" {
+			cond.Null = true
+		} else {
+			cond.Values = []any{key}
+		}
+
+		q.Where.Nodes = append(q.Where.Nodes, cond)
+	}
+
+	selected := map[string]string{}
+
+	if len(r.GroupKeys) < len(r.RowGroupCols) {
+		if err := r.group(schema, q, selected); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.order(schema, q, selected); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// group selects the next row group column and the aggregates of value columns.
+func (r *Request) group(schema *filtering.Schema, q *Query, selected map[string]string) error {
+	groupCols := []Column{r.RowGroupCols[len(r.GroupKeys)]}
+	if r.PivotMode {
+		groupCols = append(groupCols, r.PivotCols...)
+	}
+
+	for _, col := range groupCols {
+		expr, err := schema.Expr(col.ID)
+		if err != nil {
+			return err
+		}
+
+		q.Select = append(q.Select, expr+" AS "+Quote(col.ID))
+		q.GroupBy = append(q.GroupBy, expr)
+		selected[col.ID] = expr
+	}
+
+	for _, col := range r.ValueCols {
+		expr, err := schema.Expr(col.ID)
+		if err != nil {
+			return err
+		}
+
+		fn, ok := aggFuncs[col.AggFunc]
+		if !ok {
+			return fmt.Errorf("%s: %w %q", col.ID, ErrAggFunc, col.AggFunc)
+		}
+
+		q.Select = append(q.Select, fn+"("+expr+") AS "+Quote(col.ID))
+		selected[col.ID] = Quote(col.ID)
+	}
+
+	return nil
+}
+
+// order adds the sorts, group rows are sorted only by selected columns.
+func (r *Request) order(schema *filtering.Schema, q *Query, selected map[string]string) error {
+	for _, sort := range r.SortModel {
+		orders, err := schema.Orders(filtering.SortModel{sort})
+		if err != nil {
+			return err
+		}
+
+		if len(orders) == 0 {
+			continue
+		}
+
+		order := orders[0]
+
+		if q.IsGroup() {
+			expr, ok := selected[sort["colId"]]
+			if !ok {
+				continue
+			}
+
+			order.Expr = expr
+		}
+
+		q.OrderBy = append(q.OrderBy, order.String())
+	}
+
+	return nil
+}
+
+// LastRow returns lastRow of the response for the number of rows fetched with Query.Limit:
+// -1 when there are more rows, otherwise the index after the last row.
+// The extra row is not sent to the grid.
+func (r *Request) LastRow(rows int) int {
+	if r.EndRow > 0 && rows > r.EndRow-r.StartRow {
+		return -1
+	}
+
+	return r.StartRow + rows
+}
+
+// Quote quotes an SQL identifier.
+func Quote(id string) string {
+	return `"` + strings.ReplaceAll(id, `"`, `""`) + `"`
+}
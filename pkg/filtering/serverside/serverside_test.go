@@ -0,0 +1,212 @@
+package serverside
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+)
+
+var schema = &filtering.Schema{
+	Columns: map[string]filtering.Column{
+		"country": {Expr: "c.name", FilterType: "set"},
+		"year":    {Expr: "EXTRACT(YEAR FROM d.date)", FilterType: "number"},
+		"sport":   {Expr: "d.sport", FilterType: "text"},
+		"gold":    {Expr: "d.gold", FilterType: "number"},
+		"athlete": {Expr: "d.athlete", FilterType: "text"},
+	},
+}
+
+func request(t *testing.T, body string) *Request {
+	t.Helper()
+
+	r, err := ParseRequest([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestGroupQuery(t *testing.T) {
+	r := request(t, `{
+		"startRow": 100, "endRow": 200,
+		"rowGroupCols": [{"id": "country"}, {"id": "year"}],
+		"groupKeys": ["Russia"],
+		"valueCols": [{"id": "gold", "aggFunc": "sum"}],
+		"filterModel": {"sport": {"filterType": "text", "type": "equals", "filter": "Ski"}},
+		"sortModel": [{"colId": "gold", "sort": "desc"}, {"colId": "athlete", "sort": "asc"}]
+	}`)
+
+	q, err := r.Query(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Query{
+		Select: []string{
+			`EXTRACT(YEAR FROM d.date) AS "year"`,
+			`SUM(d.gold) AS "gold"`,
+		},
+		Where: &ast.Group{Logic: ast.And, Nodes: []ast.Node{
+			&ast.Cond{
+				Column: "sport",
+				Field:  "d.sport",
+				Kind:   ast.Text,
+				Op:     ast.Equals,
+				Values: []any{"Ski"},
+			},
+			&ast.Cond{
+				Column: "country",
+				Field:  "c.name",
+				Kind:   ast.Set,
+				Op:     ast.In,
+				Values: []any{"Russia"},
+			},
+		}},
+		GroupBy: []string{"EXTRACT(YEAR FROM d.date)"},
+		// сортировка по колонке, которой нет в группах, отбрасывается
+		OrderBy: []string{`"gold" DESC`},
+		Limit:   101,
+		Offset:  100,
+	}
+
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("got %#v; want %#v", q, want)
+	}
+
+	if got := r.LastRow(101); got != -1 {
+		t.Errorf("LastRow(101) = %d", got)
+	}
+
+	if got := r.LastRow(30); got != 130 {
+		t.Errorf("LastRow(30) = %d", got)
+	}
+}
+
+func TestLeafQuery(t *testing.T) {
+	r := request(t, `{
+		"startRow": 0, "endRow": 50,
+		"rowGroupCols": [{"id": "country"}],
+		"groupKeys": [""],
+		"valueCols": [{"id": "gold", "aggFunc": "sum"}],
+		"sortModel": [{"colId": "athlete", "sort": "asc"}]
+	}`)
+
+	q, err := r.Query(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q.IsGroup() || len(q.Select) != 0 {
+		t.Errorf("leaf query selects %v", q.Select)
+	}
+
+	if want := []string{"d.athlete ASC"}; !reflect.DeepEqual(q.OrderBy, want) {
+		t.Errorf("order %v; want %v", q.OrderBy, want)
+	}
+
+	if c := q.Where.Nodes[0].(*ast.Cond); !c.Null || len(c.Values) != 0 {
+		t.Errorf("empty group key must select NULL, got %#v", c)
+	}
+}
+
+func TestPivotQuery(t *testing.T) {
+	r := request(t, `{
+		"rowGroupCols": [{"id": "country"}],
+		"pivotMode": true,
+		"pivotCols": [{"id": "year"}],
+		"valueCols": [{"id": "gold", "aggFunc": "max"}]
+	}`)
+
+	q, err := r.Query(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"c.name", "EXTRACT(YEAR FROM d.date)"}; !reflect.DeepEqual(
+		q.GroupBy,
+		want,
+	) {
+		t.Errorf("group by %v; want %v", q.GroupBy, want)
+	}
+
+	if q.Limit != 0 || q.Offset != 0 {
+		t.Errorf("limit %d offset %d", q.Limit, q.Offset)
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{"range", `{"startRow": 100, "endRow": 50}`, ErrRange},
+		{"group keys", `{"groupKeys": ["a"]}`, ErrGroupKeys},
+		{
+			"aggFunc",
+			`{"rowGroupCols": [{"id": "country"}], "valueCols": [{"id": "gold", "aggFunc": "pg_sleep"}]}`,
+			ErrAggFunc,
+		},
+		{"group column", `{"rowGroupCols": [{"id": "password"}]}`, filtering.ErrColumn},
+		{"sort", `{"sortModel": [{"colId": "gold", "sort": "up"}]}`, filtering.ErrSort},
+	}
+
+	for _, c := range cases {
+		if _, err := request(t, c.body).Query(schema); !errors.Is(err, c.err) {
+			t.Errorf("%s: got %v; want %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	fm, err := filtering.ParseJSONToFilterModel(`{
+		"country": {"filterType": "set", "values": ["Russia"]},
+		"gold": {"filterType": "number", "type": "greaterThan", "filter": 0}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := SetValues(schema, "country", fm, "ru", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := "NULLIF(CAST(c.name AS text), '')"
+	want := &Values{
+		Select: value + " AS value",
+		Where: &ast.Group{Logic: ast.And, Nodes: []ast.Node{
+			&ast.Cond{
+				Column: "gold",
+				Field:  "d.gold",
+				Kind:   ast.Number,
+				Op:     ast.GreaterThan,
+				Values: []any{float64(0)},
+			},
+			&ast.Cond{
+				Column: "country",
+				Field:  value,
+				Kind:   ast.Text,
+				Op:     ast.Contains,
+				Values: []any{"ru"},
+			},
+		}},
+		OrderBy: "value ASC NULLS FIRST",
+		Limit:   50,
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("got %+v; want %+v", v, want)
+	}
+
+	if _, err := SetValues(schema, "medal", fm, "", 0); !errors.Is(err, filtering.ErrColumn) {
+		t.Errorf("got %v, want ErrColumn", err)
+	}
+
+	if len(fm) != 2 {
+		t.Errorf("filter model changed: %v", fm)
+	}
+}
@@ -0,0 +1,58 @@
+package serverside
+
+import (
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+)
+
+// Values is the query of the distinct values of a column for its set filter.
+type Values struct {
+	// Select is the value of the column as text aliased "value", empty strings are NULL
+	// like in set filters, so NULL is listed once.
+	Select string
+	// Where is the filter model without the column and the search term.
+	Where   *ast.Group
+	OrderBy string
+	// Limit is zero for all values.
+	Limit uint64
+}
+
+// SetValues builds the query of the distinct values of the column under the filters of
+// other columns. A non-empty search keeps values containing it case-insensitively.
+// Values are sorted, NULL first, so a limit never drops it.
+func SetValues(
+	schema *filtering.Schema,
+	column string,
+	fm filtering.FilterModel,
+	search string,
+	limit uint64,
+) (*Values, error) {
+	expr, err := schema.Expr(column)
+	if err != nil {
+		return nil, err
+	}
+
+	where, err := ast.ParseSchema(fm.Without(column), schema)
+	if err != nil {
+		return nil, err
+	}
+
+	value := "NULLIF(CAST(" + expr + " AS text), '')"
+
+	if search != "" {
+		where.Nodes = append(where.Nodes, &ast.Cond{
+			Column: column,
+			Field:  value,
+			Kind:   ast.Text,
+			Op:     ast.Contains,
+			Values: []any{search},
+		})
+	}
+
+	return &Values{
+		Select:  value + " AS value",
+		Where:   where,
+		OrderBy: "value ASC NULLS FIRST",
+		Limit:   limit,
+	}, nil
+}
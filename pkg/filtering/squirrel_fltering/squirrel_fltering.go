@@ -9,6 +9,10 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/keyset"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/raw_filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/serverside"
 )
 
 // CreateOrder func.
@@ -31,6 +35,12 @@ func CreateOrder(query sq.SelectBuilder, args ...string) sq.SelectBuilder {
 	}
 
 	for _, sort := range sortModel {
+		// колонка и направление попадают в SQL как есть, поэтому допускаются только
+		// идентификаторы и asc или desc
+		if _, ok := filtering.SortDesc(sort["sort"]); !ok || !filtering.ValidField(sort["colId"]) {
+			continue
+		}
+
 		if strings.Contains(sort["colId"], ".") {
 			query = query.OrderBy(fmt.Sprintf("%s %s", sort["colId"], sort["sort"]))
 		} else {
@@ -54,216 +64,162 @@ func CreateFilter(query sq.SelectBuilder, args ...string) sq.SelectBuilder {
 
 	prefix := ""
 
-	if len(args) > 1 && args[1] != "" {
-		prefix = fmt.Sprintf("%s.", args[1])
+	if len(args) > 1 {
+		prefix = args[1]
 	}
 
-	for field, filter := range filterModel {
-		if filter.IsEmpty() {
-			continue
-		}
+	tree, err := ast.Parse(filterModel, prefix)
+	if err != nil {
+		slog.Default().Info("invalid filterModel: " + err.Error())
 
-		if prefix != "" && !strings.Contains(field, ".") {
-			field = fmt.Sprintf("%s%s", prefix, field)
-		}
+		return query
+	}
+
+	return where(query, tree)
+}
 
-		var filterOperator string
+// Render converts the node to a squirrel condition with ? placeholders,
+// squirrel renumbers them for the placeholder format of the query.
+func Render(node ast.Node) sq.Sqlizer {
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
 
-		if filter.Operator != nil {
-			filterOperator = *filter.Operator
+	return sq.Expr(raw_filtering.Render(node, params), params.Args...)
+}
+
+// where adds the nodes of the group as separate conditions.
+func where(query sq.SelectBuilder, tree *ast.Group) sq.SelectBuilder {
+	for _, node := range tree.Nodes {
+		params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
+
+		if cond := raw_filtering.Render(node, params); cond != "" {
+			query = query.Where(cond, params.Args...)
 		}
+	}
+
+	return query
+}
+
+// Rank sorts the rows by the search conditions of the node, the best matches first,
+// other sorts added before it stay in front.
+func Rank(query sq.SelectBuilder, node ast.Node) sq.SelectBuilder {
+	for _, c := range ast.Searches(node) {
+		params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
 
-		switch *filter.FilterType {
-		case "set":
-			if len(filter.Values) > 0 {
-				// проверка на наличие в set null значения
-				nullExistInSet := false
-
-				for _, val := range filter.Values {
-					if *val == "" {
-						nullExistInSet = true
-
-						break
-					}
-				}
-
-				if nullExistInSet { // если null есть - то добавляем условие OR _ IS NULL
-					query = query.Where(
-						sq.Or{
-							sq.Eq{field: filter.Values},
-							sq.Expr(fmt.Sprintf("%s IS NULL", field)),
-						},
-					)
-				} else {
-					query = query.Where(sq.Eq{field: filter.Values})
-				}
-			}
-		case "date":
-			if filterOperator == "" {
-				query = constructDateWhere(query, field, filterOperator, filter)
-			} else {
-				query = constructDateWhere(query, field, filterOperator, filter.Condition1.Filter, filter.Condition2.Filter)
-			}
-		case "number":
-			query = constructNumberWhere(query, field, filter)
-		case "text":
-			if filterOperator == "" {
-				query = constructTextWhere(query, field, filterOperator, filter)
-			} else {
-				query = constructTextWhere(query, field, filterOperator, filter.Condition1.Filter, filter.Condition2.Filter)
-			}
-		default:
-			slog.Default().Info("unknown number filterType: " + *filter.FilterType)
-
-			return query
+		for _, rank := range raw_filtering.Rank(c, params) {
+			query = query.OrderByClause(rank, params.Args...)
 		}
 	}
 
 	return query
 }
 
-func constructNumberWhere(
+// CreateSchemaFilter adds conditions of the filter model JSON on the columns of the schema,
+// values are passed as arguments.
+func CreateSchemaFilter(
 	query sq.SelectBuilder,
-	field string,
-	filter filtering.Filter,
-) sq.SelectBuilder {
-	operators := map[string]string{
-		"equals":             "%s = ?",
-		"notEqual":           "%s <> ?",
-		"greaterThan":        "%s > ?",
-		"greaterThanOrEqual": "%s >= ?",
-		"lessThan":           "%s < ?",
-		"lessThanOrEqual":    "%s <= ?",
-		"inRange":            "%s between ? AND ?",
+	schema *filtering.Schema,
+	filterModel string,
+) (sq.SelectBuilder, error) {
+	if filterModel == "" {
+		return query, nil
 	}
-	query = constructQuery(
-		query,
-		operators[*filter.Type],
-		"",
-		field,
-		"",
-		fmt.Sprintf("%v", *filter.Filter),
-	)
 
-	return query
+	fm, err := filtering.ParseJSONToFilterModel(filterModel)
+	if err != nil {
+		return query, err
+	}
+
+	tree, err := ast.ParseSchema(fm, schema)
+	if err != nil {
+		return query, err
+	}
+
+	return where(query, tree), nil
 }
 
-func constructDateWhere(
+// CreateSchemaOrder adds sorts of the sort model JSON on the columns of the schema.
+func CreateSchemaOrder(
 	query sq.SelectBuilder,
-	field, operator string,
-	filters ...filtering.Filter,
-) sq.SelectBuilder {
-	var start1, start2 string
+	schema *filtering.Schema,
+	sortModel string,
+) (sq.SelectBuilder, error) {
+	if sortModel == "" {
+		return query, nil
+	}
 
-	start1 = (*filters[0].DateFrom)[0:10]
+	var sm filtering.SortModel
 
-	if operator != "" {
-		start2 = (*filters[1].DateFrom)[0:10]
+	if err := sm.Unmarshal([]byte(sortModel)); err != nil {
+		return query, err
 	}
 
-	dateOperators := map[string]string{
-		"inRange":     "DATE(%s) between ? AND ?",
-		"equals":      "DATE(%s) = ?",
-		"greaterThan": "DATE(%s) > ?",
-		"lessThan":    "DATE(%s) < ?",
-		"notEqual":    "DATE(%s) <> ?",
+	orders, err := schema.Orders(sm)
+	if err != nil {
+		return query, err
 	}
 
-	if *filters[0].Type == "inRange" {
-		end1 := (*filters[0].DateTo)[0:10]
-		if operator == "" {
-			query = query.Where(fmt.Sprintf("DATE(%s) between ? AND ?", field), start1, end1)
-		} else {
-			end2 := (*filters[1].DateTo)[0:10]
-			query = query.Where(fmt.Sprintf("DATE(%s) between ? AND ? %s DATE(%s) between ? AND ?", field, operator, field),
-				start1, end1, start2, end2)
-		}
-	} else {
-		if operator == "" {
-			query = constructQuery(
-				query,
-				dateOperators[*filters[0].Type],
-				"",
-				field,
-				operator,
-				start1)
-		} else {
-			query = constructQuery(
-				query,
-				dateOperators[*filters[0].Type],
-				dateOperators[*filters[1].Type],
-				field,
-				operator,
-				start1,
-				start2)
-		}
+	for _, o := range orders {
+		query = query.OrderBy(o.String())
 	}
 
-	return query
+	return query, nil
 }
 
-func constructTextWhere(
-	query sq.SelectBuilder,
-	field, operator string,
-	filters ...filtering.Filter,
-) sq.SelectBuilder {
-	operators := map[string]string{
-		"equals":      "lower(%s) = ?",
-		"notEqual":    "lower(%s) <> ?",
-		"contains":    "lower(%s) LIKE ?",
-		"notContains": "lower(%s) NOT LIKE ?",
-		"startsWith":  "lower(%s) LIKE ?",
-		"endsWith":    "lower(%s) LIKE ?",
+// ServerSide builds the query of a block of rows of the server-side row model on base,
+// which holds FROM, joins and the columns of leaf rows, group rows replace the columns.
+func ServerSide(base sq.SelectBuilder, q *serverside.Query) sq.SelectBuilder {
+	query := base
+	if q.IsGroup() {
+		query = query.RemoveColumns().Columns(q.Select...).GroupBy(q.GroupBy...)
 	}
-	if operator == "" {
-		query = constructQuery(
-			query,
-			operators[*filters[0].Type],
-			"",
-			field,
-			operator,
-			likeMix(*filters[0].Type, fmt.Sprintf("%v", *filters[0].Filter)))
-	} else {
-		query = constructQuery(
-			query,
-			operators[*filters[0].Type],
-			operators[*filters[1].Type],
-			field,
-			operator,
-			likeMix(*filters[0].Type, (*filters[0].Filter).(string)), //nolint:errcheck
-			likeMix(*filters[1].Type, (*filters[1].Filter).(string))) //nolint:errcheck
+
+	query = where(query, q.Where)
+
+	if len(q.OrderBy) > 0 {
+		query = query.OrderBy(q.OrderBy...)
+	}
+
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	if q.Offset > 0 {
+		query = query.Offset(q.Offset)
 	}
 
 	return query
 }
 
-func likeMix(typeOperator, filter string) (result string) {
-	likeOperators := map[string]string{
-		"contains":    "%%%s%%",
-		"notContains": "%%%s%%",
-		"startsWith":  "%s%%",
-		"endsWith":    "%%%s",
-	}
+// ServerSideCount builds the query counting all rows of the request on the level of the block,
+// for grids that need the row count instead of lastRow -1. The subquery keeps ? placeholders,
+// set the placeholder format of the result.
+func ServerSideCount(base sq.SelectBuilder, q *serverside.Query) sq.SelectBuilder {
+	rows := ServerSide(
+		base,
+		&serverside.Query{Select: q.Select, Where: q.Where, GroupBy: q.GroupBy},
+	)
+
+	return sq.Select("COUNT(*)").FromSelect(rows.PlaceholderFormat(sq.Question), "ssrm")
+}
 
-	likePhrase, ok := likeOperators[typeOperator]
-	if ok {
-		return fmt.Sprintf(likePhrase, strings.ToLower(filter))
+// SetValues builds the query of the distinct values of a set filter, base selects the rows
+// without sorting and paging, its columns are replaced. Scan the values into []*string.
+func SetValues(base sq.SelectBuilder, v *serverside.Values) sq.SelectBuilder {
+	query := where(base.RemoveColumns().Distinct().Columns(v.Select), v.Where).OrderBy(v.OrderBy)
+	if v.Limit > 0 {
+		query = query.Limit(v.Limit)
 	}
 
-	return filter
+	return query
 }
 
-func constructQuery(
-	query sq.SelectBuilder,
-	query0, query1, field, operator string,
-	params ...string,
-) sq.SelectBuilder {
-	if operator == "" {
-		query = query.Where(fmt.Sprintf(query0, field), params[0])
-	} else {
-		concatenatedQuery := fmt.Sprintf(query0, field) + " " + operator + " " + fmt.Sprintf(query1, field)
-		query = query.Where(concatenatedQuery, params[0], params[1])
+// Keyset adds the cursor condition, the sorts and the limit of the page to the query.
+// Rows of a backward page come in reverse order, pass them through keyset.Rows.
+func Keyset(query sq.SelectBuilder, page *keyset.Page) sq.SelectBuilder {
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
+	if where := page.Where(params); where != "" {
+		query = query.Where(where, params.Args...)
 	}
 
-	return query
+	return query.OrderBy(page.OrderBy()...).Limit(page.Limit())
 }
@@ -0,0 +1,248 @@
+package squirrel_fltering
+
+import (
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/keyset"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/serverside"
+)
+
+func TestCreateFilter(t *testing.T) {
+	query := sq.Select("*").From("documents d").PlaceholderFormat(sq.Dollar)
+
+	query = CreateFilter(query, `{
+		"date": {"filterType": "date", "operator": "OR",
+			"condition1": {"type": "inRange", "dateFrom": "2025-05-01 00:00:00", "dateTo": "2025-05-02 00:00:00"},
+			"condition2": {"type": "equals", "dateFrom": "2025-06-01 00:00:00"}},
+		"name": {"filterType": "text", "type": "contains", "filter": "O'Brien"}
+	}`, "d")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "SELECT * FROM documents d WHERE " +
+		"(DATE(d.date) BETWEEN $1 AND $2 OR DATE(d.date) = $3) AND lower(d.name) LIKE $4"
+	if sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+
+	if want := []any{"2025-05-01", "2025-05-02", "2025-06-01", "%o'brien%"}; !reflect.DeepEqual(
+		args,
+		want,
+	) {
+		t.Errorf("args %#v; want %#v", args, want)
+	}
+}
+
+func TestCreateFilterInvalid(t *testing.T) {
+	// dateTo не задан: фильтр пропускается вместо паники
+	query := CreateFilter(
+		sq.Select("*").From("documents"),
+		`{"date": {"filterType": "date", "type": "inRange", "dateFrom": "2025-05-01"}}`,
+	)
+
+	sql, _, err := query.ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "SELECT * FROM documents" {
+		t.Errorf("got %q", sql)
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	query := CreateOrder(sq.Select("*").From("users u"), `[
+		{"colId": "name", "sort": "asc"},
+		{"colId": "id", "sort": "desc; drop table users"},
+		{"colId": "id; drop table x --", "sort": "asc"},
+		{"colId": "owner.age", "sort": "desc"}
+	]`, "u")
+
+	sql, _, err := query.ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "SELECT * FROM users u ORDER BY u.name asc, owner.age desc"; sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+}
+
+func TestServerSide(t *testing.T) {
+	r, err := serverside.ParseRequest([]byte(`{
+		"startRow": 0, "endRow": 100,
+		"rowGroupCols": [{"id": "country"}],
+		"valueCols": [{"id": "gold", "aggFunc": "sum"}],
+		"filterModel": {"gold": {"filterType": "number", "type": "greaterThan", "filter": 0}},
+		"sortModel": [{"colId": "country", "sort": "asc"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := r.Query(&filtering.Schema{Columns: map[string]filtering.Column{
+		"country": {Expr: "c.name"},
+		"gold":    {Expr: "d.gold", FilterType: "number"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := sq.Select("d.*").From("medals d").Join("countries c ON c.id = d.country_id")
+
+	sql, args, err := ServerSide(base, q).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `SELECT c.name AS "country", SUM(d.gold) AS "gold" FROM medals d ` +
+		`JOIN countries c ON c.id = d.country_id WHERE d.gold > $1 ` +
+		`GROUP BY c.name ORDER BY c.name ASC LIMIT 101`
+	if sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+
+	if !reflect.DeepEqual(args, []any{float64(0)}) {
+		t.Errorf("args %#v", args)
+	}
+
+	sql, _, err = ServerSideCount(base, q).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = `SELECT COUNT(*) FROM (SELECT c.name AS "country", SUM(d.gold) AS "gold" FROM medals d ` +
+		`JOIN countries c ON c.id = d.country_id WHERE d.gold > $1 GROUP BY c.name) AS ssrm`
+	if sql != want {
+		t.Errorf("count got %q; want %q", sql, want)
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	pager, err := keyset.New(
+		filtering.SortModel{{"colId": "date", "sort": "desc"}},
+		&filtering.Schema{Columns: map[string]filtering.Column{
+			"id":   {Expr: "r.id"},
+			"date": {Expr: "r.created_at"},
+		}},
+		"id",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := pager.Page("", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, next, err := pager.Tokens(first, true, nil, []any{"2025-05-01", int64(42)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := pager.Page(next, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := sq.Select("*").From("egrn_requests r").Where(sq.Eq{"r.user_id": 7})
+
+	sql, args, err := Keyset(query, page).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "SELECT * FROM egrn_requests r WHERE r.user_id = $1 AND " +
+		"(r.created_at < $2 OR (r.created_at = $3 AND r.id > $4)) " +
+		"ORDER BY r.created_at DESC, r.id ASC LIMIT 51"
+	if sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+
+	if !reflect.DeepEqual(args, []any{7, "2025-05-01", "2025-05-01", int64(42)}) {
+		t.Errorf("args %#v", args)
+	}
+}
+
+func TestRank(t *testing.T) {
+	query := sq.Select("*").From("payment_documents_combo pd")
+
+	fm, err := filtering.ParseJSONToFilterModel(`{
+		"purpose": {"filterType": "search", "filter": "связь"},
+		"sum": {"filterType": "number", "type": "greaterThan", "filter": 100}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := ast.Parse(fm, "pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query = where(query, tree)
+
+	sql, args, err := Rank(query.OrderBy("pd.date DESC"), tree).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "SELECT * FROM payment_documents_combo pd " +
+		"WHERE to_tsvector('russian', pd.purpose) @@ websearch_to_tsquery('russian', $1) " +
+		"AND pd.sum > $2 ORDER BY pd.date DESC, " +
+		"ts_rank(to_tsvector('russian', pd.purpose), websearch_to_tsquery('russian', $3)) DESC"
+	if sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+
+	if !reflect.DeepEqual(args, []any{"связь", float64(100), "связь"}) {
+		t.Errorf("args %#v", args)
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	fm, err := filtering.ParseJSONToFilterModel(`{
+		"status": {"filterType": "set", "values": ["new"]},
+		"sum": {"filterType": "number", "type": "equals", "filter": 5}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := serverside.SetValues(&filtering.Schema{Columns: map[string]filtering.Column{
+		"status": {Expr: "pd.status", FilterType: "set"},
+		"sum":    {Expr: "pd.sum", FilterType: "number"},
+	}}, "status", fm, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := sq.Select("pd.*").From("payment_documents_combo pd").Where(sq.Eq{"pd.user_id": 7})
+
+	sql, args, err := SetValues(base, v).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "SELECT DISTINCT NULLIF(CAST(pd.status AS text), '') AS value " +
+		"FROM payment_documents_combo pd WHERE pd.user_id = $1 AND pd.sum = $2 " +
+		"ORDER BY value ASC NULLS FIRST"
+	if sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+
+	if !reflect.DeepEqual(args, []any{7, float64(5)}) {
+		t.Errorf("args %#v", args)
+	}
+}
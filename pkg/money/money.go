@@ -0,0 +1,301 @@
+// Package money implements an exact fixed-point amount with two decimal places (rubles and kopecks)
+// as used in 1C exchange files, bank statements and accounting tables.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor units (kopecks).
+type Money int64
+
+const (
+	Zero Money = 0
+	// scale минорных единиц в основной
+	scale = 100
+)
+
+var (
+	ErrEmpty     = errors.New("empty amount")
+	ErrSyntax    = errors.New("invalid amount")
+	ErrPrecision = errors.New("amount has more than two decimal places")
+	ErrOverflow  = errors.New("amount is out of range")
+	ErrScan      = errors.New("unsupported amount type")
+)
+
+// FromMinor creates an amount from kopecks.
+func FromMinor(minor int64) Money {
+	return Money(minor)
+}
+
+// FromFloat rounds a float amount to kopecks, half away from zero.
+func FromFloat(f float64) Money {
+	return Money(math.Round(f * scale))
+}
+
+// Parse reads an amount in 1C notation: "1234.56", "-1234,5", "1234".
+// Spaces used as thousand separators are ignored, more than two non-zero decimal places is an error.
+func Parse(s string) (Money, error) {
+	s = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' || r == '\t' {
+			return -1
+		}
+
+		return r
+	}, s)
+	if s == "" {
+		return Zero, ErrEmpty
+	}
+
+	negative := false
+
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	units, frac, _ := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+	if units == "" && frac == "" || !isDigits(units) || !isDigits(frac) {
+		return Zero, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	// лишние разряды дробной части допустимы, только если это нули
+	if len(frac) > 2 {
+		if strings.Trim(frac[2:], "0") != "" {
+			return Zero, fmt.Errorf("%w: %q", ErrPrecision, s)
+		}
+
+		frac = frac[:2]
+	}
+
+	frac += strings.Repeat("0", 2-len(frac))
+
+	if units == "" {
+		units = "0"
+	}
+
+	value, err := strconv.ParseInt(units+frac, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+
+	if negative {
+		value = -value
+	}
+
+	return Money(value), nil
+}
+
+// MustParse is Parse that panics on error, for constants and tests.
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Minor returns the amount in kopecks.
+func (m Money) Minor() int64 {
+	return int64(m)
+}
+
+// Float64 returns the amount in rubles, for legacy APIs only.
+func (m Money) Float64() float64 {
+	return float64(m) / scale
+}
+
+// String formats the amount as "1234.56", the form 1C files use.
+func (m Money) String() string {
+	sign := ""
+	minor := uint64(m) //nolint:gosec // модуль берется ниже для отрицательных
+
+	if m < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, minor/scale, minor%scale)
+}
+
+func (m Money) Add(o Money) Money {
+	return m + o
+}
+
+func (m Money) Sub(o Money) Money {
+	return m - o
+}
+
+// Mul multiplies the amount by n, ErrOverflow is returned when the product does not fit in int64.
+func (m Money) Mul(n int64) (Money, error) {
+	hi, lo := bits.Mul64(abs(int64(m)), abs(n))
+
+	negative := (m < 0) != (n < 0)
+
+	// модуль отрицательного произведения может быть на единицу больше MaxInt64
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+
+	if hi != 0 || lo > limit {
+		return Zero, fmt.Errorf("%w: %s * %d", ErrOverflow, m, n)
+	}
+
+	if negative {
+		lo = -lo
+	}
+
+	return Money(lo), nil //nolint:gosec // диапазон проверен выше
+}
+
+func (m Money) Neg() Money {
+	return -m
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+
+	return m
+}
+
+// Cmp returns -1, 0 or +1 as m is less than, equal to or greater than o.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m < o:
+		return -1
+	case m > o:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// Sum adds up amounts.
+func Sum(amounts ...Money) Money {
+	var res Money
+
+	for _, a := range amounts {
+		res += a
+	}
+
+	return res
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, an empty text is zero as in 1C files
+// where empty totals (ВсегоСписано=) are common.
+func (m *Money) UnmarshalText(text []byte) error {
+	if len(strings.TrimSpace(string(text))) == 0 {
+		*m = Zero
+
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
+
+// MarshalJSON writes the amount as a JSON number, compatible with former float64 fields.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, a string or null.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	return m.UnmarshalText([]byte(strings.Trim(s, `"`)))
+}
+
+// Value implements driver.Valuer, the amount is passed as a decimal string for NUMERIC columns.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner for NUMERIC, floating point and integer (whole units) columns.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Zero
+	case string:
+		return m.UnmarshalText([]byte(v))
+	case []byte:
+		return m.UnmarshalText(v)
+	case float64:
+		// float64(MaxInt64) округляется до 2^63, поэтому граница не включается
+		if math.IsNaN(v) || math.Abs(math.Round(v*scale)) >= math.MaxInt64 {
+			return fmt.Errorf("%w: %v", ErrOverflow, v)
+		}
+
+		*m = FromFloat(v)
+	case int64:
+		res, err := Money(v).Mul(scale)
+		if err != nil {
+			return err
+		}
+
+		*m = res
+	default:
+		return fmt.Errorf("%w: %T", ErrScan, src)
+	}
+
+	return nil
+}
+
+// abs returns the modulus of n, MinInt64 included.
+func abs(n int64) uint64 {
+	u := uint64(n) //nolint:gosec // модуль берется ниже для отрицательных
+	if n < 0 {
+		u = -u
+	}
+
+	return u
+}
@@ -0,0 +1,174 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+		err  error
+	}{
+		{"1234.56", 123456, nil},
+		{"1234,56", 123456, nil},
+		{"1234", 123400, nil},
+		{"1234.5", 123450, nil},
+		{".5", 50, nil},
+		{"-0.01", -1, nil},
+		{"+10", 1000, nil},
+		{"1 234 567.89", 123456789, nil},
+		{"100.000", 10000, nil},
+		{"", 0, ErrEmpty},
+		{"-", 0, ErrSyntax},
+		{"12a.00", 0, ErrSyntax},
+		{"1.2.3", 0, ErrSyntax},
+		{"0.005", 0, ErrPrecision},
+		{"99999999999999999999", 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.in, err, tt.err)
+		}
+
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for m, want := range map[Money]string{
+		0:       "0.00",
+		5:       "0.05",
+		-5:      "-0.05",
+		123456:  "1234.56",
+		-123400: "-1234.00",
+	} {
+		if got := m.String(); got != want {
+			t.Errorf("Money(%d).String() = %q, want %q", m, got, want)
+		}
+	}
+}
+
+func TestSumIsExact(t *testing.T) {
+	var total Money
+
+	// во float64 такая сумма дает 1000.0000000001588
+	for range 10000 {
+		total = total.Add(MustParse("0.10"))
+	}
+
+	if total != MustParse("1000") {
+		t.Errorf("sum = %s, want 1000.00", total)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type doc struct {
+		Summ Money  `json:"summ"`
+		Ptr  *Money `json:"ptr"`
+	}
+
+	data, err := json.Marshal(doc{Summ: 123450})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"summ":1234.50,"ptr":null}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+
+	var got doc
+	if err := json.Unmarshal([]byte(`{"summ":"12.3","ptr":0.01}`), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Summ != 1230 || got.Ptr == nil || *got.Ptr != 1 {
+		t.Errorf("unexpected value %+v", got)
+	}
+}
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		m    Money
+		n    int64
+		want Money
+	}{
+		{150, 3, 450},
+		{150, -3, -450},
+		{-150, -3, 450},
+		{0, math.MinInt64, 0},
+		{1, math.MinInt64, math.MinInt64},
+		{-1, math.MaxInt64, -math.MaxInt64},
+		{math.MaxInt64, 1, math.MaxInt64},
+		{-(1 << 32), 1 << 31, math.MinInt64},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.m.Mul(tt.n)
+		if err != nil || got != tt.want {
+			t.Errorf("%d.Mul(%d) = %d, %v; want %d", tt.m, tt.n, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		m Money
+		n int64
+	}{
+		{math.MaxInt64, 2},
+		{math.MinInt64, -1},
+		{-1, math.MinInt64},
+		{1 << 32, 1 << 31},
+		{-(1 << 32), 1<<31 + 1},
+	} {
+		if _, err := tt.m.Mul(tt.n); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%d.Mul(%d) error = %v, want ErrOverflow", tt.m, tt.n, err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want Money
+	}{
+		{"1234.56", 123456},
+		{[]byte("0.10"), 10},
+		{0.1 + 0.2, 30},
+		{int64(12), 1200},
+		{nil, 0},
+	}
+
+	for _, tt := range tests {
+		var m Money
+		if err := m.Scan(tt.src); err != nil {
+			t.Errorf("Scan(%v) error %v", tt.src, err)
+		}
+
+		if m != tt.want {
+			t.Errorf("Scan(%v) = %d, want %d", tt.src, m, tt.want)
+		}
+	}
+
+	var m Money
+	if err := m.Scan(true); !errors.Is(err, ErrScan) {
+		t.Errorf("Scan(bool) error = %v, want ErrScan", err)
+	}
+
+	for _, src := range []any{int64(math.MaxInt64 / 10), int64(math.MinInt64), 1e17, math.NaN()} {
+		if err := m.Scan(src); !errors.Is(err, ErrOverflow) {
+			t.Errorf("Scan(%v) error = %v, want ErrOverflow", src, err)
+		}
+	}
+
+	value, _ := Money(-1).Value()
+	if value != "-0.01" {
+		t.Errorf("Value() = %v", value)
+	}
+}
@@ -5,20 +5,21 @@ import (
 	"time"
 
 	pb "github.com/SOTBI-LLC/sotbi.lib/pkg/api/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
 )
 
 type AccountBalance struct {
-	ID             uint64     `json:"id"`
-	ExchangeFileID uint64     `json:"exchange_file_id"`
-	StartDateStr   string     `json:"-"                    mapstructure:"ДатаНачала"`
-	StartDate      *time.Time `json:"start_date,omitempty" mapstructure:"-"`
-	EndDateStr     string     `json:"-"                    mapstructure:"ДатаКонца"`
-	EndDate        *time.Time `json:"end_date,omitempty"   mapstructure:"-"`
-	Account        string     `json:"account,omitempty"    mapstructure:"РасчСчет"`
-	InitialBalance float64    `json:"initial_balance"      mapstructure:"НачальныйОстаток"`
-	Income         float64    `json:"income"               mapstructure:"ВсегоПоступило"`
-	WriteOff       float64    `json:"write_off"            mapstructure:"ВсегоСписано"`
-	FinalBalance   float64    `json:"final_balance"        mapstructure:"КонечныйОстаток"`
+	ID             uint64      `json:"id"`
+	ExchangeFileID uint64      `json:"exchange_file_id"`
+	StartDateStr   string      `json:"-"                    mapstructure:"ДатаНачала"`
+	StartDate      *time.Time  `json:"start_date,omitempty" mapstructure:"-"`
+	EndDateStr     string      `json:"-"                    mapstructure:"ДатаКонца"`
+	EndDate        *time.Time  `json:"end_date,omitempty"   mapstructure:"-"`
+	Account        string      `json:"account,omitempty"    mapstructure:"РасчСчет"`
+	InitialBalance money.Money `json:"initial_balance"      mapstructure:"НачальныйОстаток"`
+	Income         money.Money `json:"income"               mapstructure:"ВсегоПоступило"`
+	WriteOff       money.Money `json:"write_off"            mapstructure:"ВсегоСписано"`
+	FinalBalance   money.Money `json:"final_balance"        mapstructure:"КонечныйОстаток"`
 }
 
 func (b *AccountBalance) ToPB(request *pb.ParseRequest) *pb.ParseResponse {
@@ -30,16 +31,27 @@ func (b *AccountBalance) ToPB(request *pb.ParseRequest) *pb.ParseResponse {
 		DebtorId:     request.DebtorId,
 		Item: &pb.ParseResponse_Balance{
 			Balance: &pb.AccountBalance{
-				Id:             b.ID,
-				StartDate:      timeToTimestamppb(b.StartDate),
-				EndDate:        timeToTimestamppb(b.EndDate),
-				Account:        strings.TrimSpace(b.Account),
-				InitialBalance: b.InitialBalance,
-				Income:         b.Income,
-				WriteOff:       b.WriteOff,
-				FinalBalance:   b.FinalBalance,
-				ExchangeFileId: b.ExchangeFileID,
+				Id:                  b.ID,
+				StartDate:           timeToTimestamppb(b.StartDate),
+				EndDate:             timeToTimestamppb(b.EndDate),
+				Account:             strings.TrimSpace(b.Account),
+				InitialBalance:      b.InitialBalance.Float64(),
+				Income:              b.Income.Float64(),
+				WriteOff:            b.WriteOff.Float64(),
+				FinalBalance:        b.FinalBalance.Float64(),
+				InitialBalanceMinor: b.InitialBalance.Minor(),
+				IncomeMinor:         b.Income.Minor(),
+				WriteOffMinor:       b.WriteOff.Minor(),
+				FinalBalanceMinor:   b.FinalBalance.Minor(),
+				ExchangeFileId:      b.ExchangeFileID,
 			},
 		},
 	}
 }
+
+// SetPeriod fills ДатаНачала and ДатаКонца the way the exchange file parser does,
+// the end date covers the whole day in Moscow time.
+func (b *AccountBalance) SetPeriod(start, end time.Time) {
+	b.StartDateStr, b.StartDate = periodStart(start)
+	b.EndDateStr, b.EndDate = periodEnd(end)
+}
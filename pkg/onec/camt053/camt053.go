@@ -0,0 +1,434 @@
+// Package camt053 converts ISO 20022 CAMT.053 bank to customer statements into the onec model,
+// so they go through the same pipeline as 1CClientBankExchange files.
+// Elements are matched by local names, fields that moved between versions of the schema
+// (transaction amounts, party names) are read from both places, camt.053.001.02 and later are accepted.
+package camt053
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+// FormatVer is the ВерсияФормата of results converted from CAMT.053.
+const FormatVer = "CAMT.053"
+
+const (
+	documentPayment = "Платежное поручение"
+	documentOrder   = "Банковский ордер"
+
+	debit = "DBIT"
+	// booked проведенная запись, PDNG и INFO еще не изменили остаток
+	booked = "BOOK"
+
+	// schemeINN схема идентификатора организации, под которой российские банки передают ИНН
+	schemeINN = "TXID"
+)
+
+var (
+	ErrNoStatement = errors.New("no CAMT.053 statement found")
+	ErrAmount      = errors.New("invalid CAMT.053 amount")
+	ErrTxAmount    = errors.New("no amount of a CAMT.053 batch transaction")
+)
+
+// Parser reads CAMT.053 statements, the XML declaration defines the encoding.
+type Parser struct{}
+
+var _ onec.Parser = (*Parser)(nil)
+
+// Scan converts every Stmt into an AccountBalance and every transaction of its booked entries
+// (TxDtls, or the entry itself without details) into a PaymentDocument. Pending and
+// informational entries are skipped, they do not change the balance.
+func (p *Parser) Scan(
+	ctx context.Context,
+	file io.Reader,
+	ids onec.IDAllocator,
+) (*onec.Result, error) {
+	var doc document
+
+	// без объявления encoding XML читается как UTF-8
+	encoding := onec.EncodingUTF8
+
+	decoder := xml.NewDecoder(file)
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		var (
+			r   io.Reader
+			err error
+		)
+
+		r, encoding, err = charsetReader(label, input)
+
+		return r, err
+	}
+
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error while decoding CAMT.053: %w", err)
+	}
+
+	if len(doc.Statements) == 0 {
+		return nil, ErrNoStatement
+	}
+
+	result := &onec.Result{
+		Remainings:       make([]onec.AccountBalance, 0, len(doc.Statements)),
+		PaymentDocuments: []onec.PaymentDocument{},
+	}
+
+	for i := range doc.Statements {
+		balance, documents, err := doc.Statements[i].convert()
+		if err != nil {
+			return nil, fmt.Errorf("statement %q: %w", doc.Statements[i].ID, err)
+		}
+
+		result.Remainings = append(result.Remainings, balance)
+		result.PaymentDocuments = append(result.PaymentDocuments, documents...)
+	}
+
+	result.ExchangeFile = onec.NewStatementHeader(FormatVer, result.Remainings)
+	result.ExchangeFile.CreatedDate = doc.Header.Created.time()
+	result.ExchangeFile.DetectedEncoding = encoding
+
+	if err := result.AssignIDs(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	return result.ProcessBalanceAndDocs(), nil
+}
+
+type document struct {
+	Header struct {
+		Created dateTime `xml:"CreDtTm"`
+	} `xml:"BkToCstmrStmt>GrpHdr"`
+	Statements []statement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type statement struct {
+	ID       string    `xml:"Id"`
+	Account  account   `xml:"Acct"`
+	From     dateTime  `xml:"FrToDt>FrDtTm"`
+	To       dateTime  `xml:"FrToDt>ToDtTm"`
+	Balances []balance `xml:"Bal"`
+	Entries  []entry   `xml:"Ntry"`
+}
+
+type account struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+	// БИК банка счета выписки
+	Member string `xml:"Svcr>FinInstnId>ClrSysMmbId>MmbId"`
+}
+
+func (a account) number() string {
+	return strings.TrimSpace(first(a.Other, a.IBAN))
+}
+
+func (a account) bik() string {
+	return strings.TrimSpace(a.Member)
+}
+
+type balance struct {
+	Code   string   `xml:"Tp>CdOrPrtry>Cd"`
+	Amount string   `xml:"Amt"`
+	Sign   string   `xml:"CdtDbtInd"`
+	Date   dateTime `xml:"Dt>Dt"`
+	Time   dateTime `xml:"Dt>DtTm"`
+}
+
+type entry struct {
+	Amount    string   `xml:"Amt"`
+	Sign      string   `xml:"CdtDbtInd"`
+	Reversal  bool     `xml:"RvslInd"`
+	Status    status   `xml:"Sts"`
+	Booked    dateTime `xml:"BookgDt>Dt"`
+	BookedTm  dateTime `xml:"BookgDt>DtTm"`
+	Value     dateTime `xml:"ValDt>Dt"`
+	Reference string   `xml:"AcctSvcrRef"`
+	SubFamily string   `xml:"BkTxCd>Domn>Fmly>SubFmlyCd"`
+	Details   []txn    `xml:"NtryDtls>TxDtls"`
+	Info      string   `xml:"AddtlNtryInf"`
+}
+
+// status is Sts of camt.053.001.02-07 and Sts/Cd of the later versions.
+type status struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+// isBooked reports whether the entry is booked, an entry without a status is taken as booked.
+func (e *entry) isBooked() bool {
+	code := strings.TrimSpace(first(e.Status.Code, e.Status.Text))
+
+	return code == "" || code == booked
+}
+
+type txn struct {
+	// TxDtls/Amt появилась в camt.053.001.04, раньше сумма была только в AmtDtls
+	Amount      string   `xml:"Amt"`
+	TxAmount    string   `xml:"AmtDtls>TxAmt>Amt"`
+	InstdAmount string   `xml:"AmtDtls>InstdAmt>Amt"`
+	EndToEndID  string   `xml:"Refs>EndToEndId"`
+	InstrID     string   `xml:"Refs>InstrId"`
+	Debtor      party    `xml:"RltdPties>Dbtr"`
+	DebtorAcct  account  `xml:"RltdPties>DbtrAcct"`
+	Creditor    party    `xml:"RltdPties>Cdtr"`
+	CreditorAct account  `xml:"RltdPties>CdtrAcct"`
+	DebtorAgt   agent    `xml:"RltdAgts>DbtrAgt"`
+	CreditorAgt agent    `xml:"RltdAgts>CdtrAgt"`
+	Purpose     []string `xml:"RmtInf>Ustrd"`
+	Info        string   `xml:"AddtlTxInf"`
+}
+
+// party covers Dbtr/Nm of camt.053.001.02-07 and Dbtr/Pty/Nm of the later versions.
+type party struct {
+	Name      string  `xml:"Nm"`
+	PartyName string  `xml:"Pty>Nm"`
+	IDs       []orgID `xml:"Id>OrgId>Othr"`
+	PartyIDs  []orgID `xml:"Pty>Id>OrgId>Othr"`
+}
+
+type orgID struct {
+	ID     string `xml:"Id"`
+	Scheme string `xml:"SchmeNm>Cd"`
+}
+
+func (p party) name() string {
+	return strings.TrimSpace(first(p.Name, p.PartyName))
+}
+
+func (p party) inn() string {
+	for _, id := range slices.Concat(p.IDs, p.PartyIDs) {
+		if id.Scheme == schemeINN {
+			return strings.TrimSpace(id.ID)
+		}
+	}
+
+	return ""
+}
+
+// agent is a bank of a party, Russian banks put the BIK into the clearing system member id.
+type agent struct {
+	Member string `xml:"FinInstnId>ClrSysMmbId>MmbId"`
+}
+
+// dateTime accepts xs:date and xs:dateTime values.
+type dateTime string
+
+func (d dateTime) time() *time.Time {
+	s := strings.TrimSpace(string(d))
+	if s == "" {
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+
+	return nil
+}
+
+func (st *statement) convert() (onec.AccountBalance, []onec.PaymentDocument, error) {
+	b := onec.AccountBalance{Account: st.Account.number()}
+
+	var opening, closing *time.Time
+
+	for _, bal := range st.Balances {
+		amount, err := signed(bal.Amount, bal.Sign)
+		if err != nil {
+			return b, nil, err
+		}
+
+		date := first(bal.Date, bal.Time).time()
+
+		switch bal.Code {
+		case "OPBD", "PRCD":
+			b.InitialBalance, opening = amount, date
+		case "CLBD":
+			b.FinalBalance, closing = amount, date
+		}
+	}
+
+	var documents []onec.PaymentDocument
+
+	for i := range st.Entries {
+		e := &st.Entries[i]
+		if !e.isBooked() {
+			continue
+		}
+
+		docs, err := st.entryDocuments(e)
+		if err != nil {
+			return b, nil, err
+		}
+
+		for _, d := range docs {
+			if e.isDebit() {
+				b.WriteOff = b.WriteOff.Add(d.Summ)
+			} else {
+				b.Income = b.Income.Add(d.Summ)
+			}
+		}
+
+		documents = append(documents, docs...)
+	}
+
+	start, end := first(st.From.time(), opening), first(st.To.time(), closing)
+	if start == nil {
+		start = end
+	}
+
+	if end == nil {
+		end = start
+	}
+
+	if start != nil {
+		b.SetPeriod(*start, *end)
+	}
+
+	return b, documents, nil
+}
+
+func (st *statement) entryDocuments(e *entry) ([]onec.PaymentDocument, error) {
+	booked := first(e.Booked, e.BookedTm, e.Value).time()
+
+	details := e.Details
+	if len(details) == 0 {
+		details = []txn{{}}
+	}
+
+	res := make([]onec.PaymentDocument, 0, len(details))
+
+	for _, t := range details {
+		value := first(t.Amount, t.TxAmount, t.InstdAmount)
+		if value == "" {
+			// сумма проводки пакета делится между транзакциями, взять ее целиком нельзя
+			if len(details) > 1 {
+				return nil, fmt.Errorf("%w: entry %q", ErrTxAmount, e.Reference)
+			}
+
+			value = e.Amount
+		}
+
+		amount, err := money.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAmount, err)
+		}
+
+		doc := onec.PaymentDocument{
+			DocumentType:   documentPayment,
+			Number:         strings.TrimSpace(first(t.InstrID, t.EndToEndID, e.Reference)),
+			Summ:           amount,
+			PaymentPurpose: strings.TrimSpace(first(strings.Join(t.Purpose, ""), t.Info, e.Info)),
+		}
+
+		// комиссии и проценты банка
+		switch e.SubFamily {
+		case "CHRG", "COMM", "INTR":
+			doc.DocumentType = documentOrder
+		}
+
+		if booked != nil {
+			doc.DataStr, doc.Data = documentDate(*booked)
+		}
+
+		doc.PayerAccount = t.DebtorAcct.number()
+		doc.Payer, doc.PayerINN = t.Debtor.name(), t.Debtor.inn()
+		doc.PayerBIK = strings.TrimSpace(t.DebtorAgt.Member)
+		doc.ReceiverAccount = t.CreditorAct.number()
+		doc.Receiver, doc.ReceiverINN = t.Creditor.name(), t.Creditor.inn()
+		doc.ReceiverBIK = strings.TrimSpace(t.CreditorAgt.Member)
+
+		// своя сторона проводки берется из счета выписки
+		if e.isDebit() {
+			doc.PayerAccount, doc.PayerBIK = st.Account.number(), first(
+				st.Account.bik(),
+				doc.PayerBIK,
+			)
+
+			if booked != nil {
+				doc.WrittenOffDateStr, doc.WrittenOffDate = documentDate(*booked)
+			}
+		} else {
+			doc.ReceiverAccount, doc.ReceiverBIK = st.Account.number(), first(st.Account.bik(), doc.ReceiverBIK)
+
+			if booked != nil {
+				doc.IncomeDateStr, doc.IncomeDate = documentDate(*booked)
+			}
+		}
+
+		doc.PayerCurrentAccount, doc.ReceiverCurrentAccount = doc.PayerAccount, doc.ReceiverAccount
+
+		res = append(res, doc)
+	}
+
+	return res, nil
+}
+
+// isDebit reports whether the entry is written off the statement account, a reversal changes the direction.
+func (e *entry) isDebit() bool {
+	return (e.Sign == debit) != e.Reversal
+}
+
+func signed(amount, sign string) (money.Money, error) {
+	m, err := money.Parse(amount)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrAmount, err)
+	}
+
+	if sign == debit {
+		m = m.Neg()
+	}
+
+	return m, nil
+}
+
+// charsetReader decodes statements declared in single-byte encodings, e.g. windows-1251,
+// and returns the canonical name of the encoding.
+func charsetReader(label string, input io.Reader) (io.Reader, string, error) {
+	enc, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, "", fmt.Errorf("unsupported encoding %q: %w", label, err)
+	}
+
+	name, err := htmlindex.Name(enc)
+	if err != nil {
+		name = strings.ToLower(label)
+	}
+
+	// htmlindex называет CP866 ibm866
+	if name == "ibm866" {
+		name = onec.EncodingCP866
+	}
+
+	return enc.NewDecoder().Reader(input), name, nil
+}
+
+func documentDate(t time.Time) (string, *time.Time) {
+	str := t.Format("02.01.2006")
+
+	return str, onec.ParseDate(str)
+}
+
+// first returns the first non-zero value.
+func first[T comparable](values ...T) T {
+	var zero T
+
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+
+	return zero
+}
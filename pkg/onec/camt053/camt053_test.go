@@ -0,0 +1,173 @@
+package camt053
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+)
+
+type CAMT053TestSuite struct {
+	suite.Suite
+}
+
+func (suite *CAMT053TestSuite) TestScan() {
+	file, err := os.Open("fixtures/statement.xml")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	result, err := (&Parser{}).Scan(context.Background(), file, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	header := result.ExchangeFile
+	suite.Equal(uint64(1), header.ID)
+	suite.Equal(FormatVer, header.FormatVer)
+	suite.Equal([]string{"40702810000000000001"}, header.Account)
+	suite.Equal("01.03.2025", header.StartDateStr)
+	suite.Equal("02.03.2025", header.EndDateStr)
+	suite.NotNil(header.CreatedDate)
+	suite.Equal(onec.EncodingUTF8, header.DetectedEncoding)
+
+	suite.Require().Len(result.Remainings, 1)
+	balance := result.Remainings[0]
+	suite.Equal(uint64(2), balance.ID)
+	suite.Equal(money.MustParse("1000"), balance.InitialBalance)
+	suite.Equal(money.MustParse("250"), balance.Income)
+	suite.Equal(money.MustParse("110.50"), balance.WriteOff)
+	suite.Equal(money.MustParse("1139.50"), balance.FinalBalance)
+
+	suite.Require().Len(result.PaymentDocuments, 3)
+
+	payment := result.PaymentDocuments[0]
+	suite.Equal("Платежное поручение", payment.DocumentType)
+	suite.Equal("15", payment.Number)
+	suite.Equal("01.03.2025", payment.WrittenOffDateStr)
+	suite.Equal("40702810000000000001", payment.PayerAccount)
+	suite.Equal("044525225", payment.PayerBIK)
+	suite.Equal("40702810900000000002", payment.ReceiverAccount)
+	suite.Equal("044525593", payment.ReceiverBIK)
+	suite.Equal(`ООО "Ромашка"`, payment.Receiver)
+	suite.Equal("7707083893", payment.ReceiverINN)
+	suite.Equal("Оплата по договору N 7 от 01.02.2025, без НДС", payment.PaymentPurpose)
+	suite.Equal(balance.ID, payment.AccountBalanceID)
+
+	income := result.PaymentDocuments[1]
+	suite.Equal("B2", income.Number)
+	suite.Equal("ИП Иванов", income.Payer)
+	suite.Equal("40802810000000000003", income.PayerAccount)
+	suite.Equal("40702810000000000001", income.ReceiverAccount)
+	suite.Equal("01.03.2025", income.IncomeDateStr)
+
+	charge := result.PaymentDocuments[2]
+	suite.Equal("Банковский ордер", charge.DocumentType)
+	suite.Equal("Комиссия за ведение счета", charge.PaymentPurpose)
+
+	suite.True(result.Reconcile().IsConsistent())
+}
+
+func (suite *CAMT053TestSuite) TestScanWindows1251() {
+	data, err := os.ReadFile("fixtures/statement.xml")
+	suite.Require().NoError(err)
+
+	encoded, err := charmap.Windows1251.NewEncoder().Bytes(
+		[]byte(strings.Replace(string(data), "UTF-8", "windows-1251", 1)),
+	)
+	suite.Require().NoError(err)
+
+	result, err := (&Parser{}).Scan(
+		context.Background(),
+		bytes.NewReader(encoded),
+		ids.NewCounter(0),
+	)
+	suite.Require().NoError(err)
+
+	suite.Equal(onec.EncodingWindows1251, result.ExchangeFile.DetectedEncoding)
+	suite.Equal(`ООО "Ромашка"`, result.PaymentDocuments[0].Receiver)
+}
+
+func (suite *CAMT053TestSuite) TestScanBatch() {
+	file, err := os.Open("fixtures/batch.xml")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	result, err := (&Parser{}).Scan(context.Background(), file, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	suite.Require().Len(result.Remainings, 1)
+	suite.Equal(money.MustParse("300"), result.Remainings[0].WriteOff)
+	suite.True(result.Remainings[0].Income.IsZero())
+
+	suite.Require().Len(result.PaymentDocuments, 2)
+	suite.Equal("21", result.PaymentDocuments[0].Number)
+	suite.Equal(money.MustParse("120"), result.PaymentDocuments[0].Summ)
+	suite.Equal("Петров Петр Петрович", result.PaymentDocuments[0].Receiver)
+	suite.Equal("22", result.PaymentDocuments[1].Number)
+	suite.Equal(money.MustParse("180"), result.PaymentDocuments[1].Summ)
+
+	suite.True(result.Reconcile().IsConsistent())
+}
+
+func (suite *CAMT053TestSuite) TestScanStatus() {
+	entry := func(amount, sts string) string {
+		return "<Ntry><Amt>" + amount + "</Amt><CdtDbtInd>CRDT</CdtDbtInd>" + sts +
+			"<BookgDt><Dt>2025-03-05</Dt></BookgDt><AcctSvcrRef>" + amount + "</AcctSvcrRef></Ntry>"
+	}
+
+	result, err := (&Parser{}).Scan(context.Background(), strings.NewReader(
+		"<Document><BkToCstmrStmt><Stmt>"+
+			"<Acct><Id><Othr><Id>40702810000000000001</Id></Othr></Id></Acct>"+
+			entry("1.00", "<Sts>BOOK</Sts>")+
+			entry("2.00", "<Sts>PDNG</Sts>")+
+			entry("4.00", "<Sts><Cd>BOOK</Cd></Sts>")+
+			entry("8.00", "<Sts><Cd>INFO</Cd></Sts>")+
+			entry("16.00", "")+
+			"</Stmt></BkToCstmrStmt></Document>",
+	), ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	// ожидающие и информационные записи не попадают в документы и обороты
+	var summs []money.Money
+	for _, d := range result.PaymentDocuments {
+		summs = append(summs, d.Summ)
+	}
+
+	suite.Equal(
+		[]money.Money{money.MustParse("1"), money.MustParse("4"), money.MustParse("16")},
+		summs,
+	)
+	suite.Equal(money.MustParse("21"), result.Remainings[0].Income)
+}
+
+func (suite *CAMT053TestSuite) TestErrors() {
+	_, err := (&Parser{}).Scan(
+		context.Background(),
+		strings.NewReader("<Document/>"),
+		ids.NewCounter(0),
+	)
+	suite.ErrorIs(err, ErrNoStatement)
+
+	_, err = (&Parser{}).Scan(context.Background(), strings.NewReader(
+		"<Document><BkToCstmrStmt><Stmt><Ntry><Amt>1,2,3</Amt></Ntry></Stmt></BkToCstmrStmt></Document>",
+	), ids.NewCounter(0))
+	suite.ErrorIs(err, ErrAmount)
+
+	_, err = (&Parser{}).Scan(context.Background(), strings.NewReader(
+		"<Document><BkToCstmrStmt><Stmt><Ntry><Amt>3.00</Amt><AcctSvcrRef>R1</AcctSvcrRef>"+
+			"<NtryDtls><TxDtls/><TxDtls/></NtryDtls></Ntry></Stmt></BkToCstmrStmt></Document>",
+	), ids.NewCounter(0))
+	suite.ErrorIs(err, ErrTxAmount)
+}
+
+func TestCAMT053TestSuite(t *testing.T) {
+	suite.Run(t, new(CAMT053TestSuite))
+}
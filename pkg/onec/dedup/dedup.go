@@ -0,0 +1,142 @@
+// Package dedup finds payment documents already imported from other exchange files.
+// Overlapping statement periods of the same account repeat documents, they are recognized
+// by PaymentDocument.Fingerprint, and a document with a known IdentityKey but another
+// fingerprint is reported as a conflict (e.g. the bank changed the purpose or the amount).
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+type Status string
+
+const (
+	StatusNew       Status = "new"
+	StatusDuplicate Status = "duplicate"
+	StatusConflict  Status = "conflict"
+)
+
+// Finding is the status of a document of a checked result.
+type Finding struct {
+	// Result is the index of the result in the Check arguments, Index is the index of the document in it.
+	Result      int                   `json:"result"`
+	Index       int                   `json:"index"`
+	Document    *onec.PaymentDocument `json:"-"`
+	Status      Status                `json:"status"`
+	Identity    string                `json:"identity"`
+	Fingerprint string                `json:"fingerprint"`
+	// Known are fingerprints of the conflicting versions.
+	Known []string `json:"known,omitempty"`
+}
+
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+func (r *Report) byStatus(status Status) []Finding {
+	res := make([]Finding, 0, len(r.Findings))
+
+	for _, f := range r.Findings {
+		if f.Status == status {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+func (r *Report) New() []Finding {
+	return r.byStatus(StatusNew)
+}
+
+func (r *Report) Duplicates() []Finding {
+	return r.byStatus(StatusDuplicate)
+}
+
+func (r *Report) Conflicts() []Finding {
+	return r.byStatus(StatusConflict)
+}
+
+// Documents returns new documents only, in the order of the checked results.
+func (r *Report) Documents() []onec.PaymentDocument {
+	res := make([]onec.PaymentDocument, 0, len(r.Findings))
+
+	for _, f := range r.byStatus(StatusNew) {
+		res = append(res, *f.Document)
+	}
+
+	return res
+}
+
+// RecordOf returns the record of a document, e.g. to seed a store with already imported documents.
+func RecordOf(doc *onec.PaymentDocument) Record {
+	return Record{Identity: doc.IdentityKey(), Fingerprint: doc.Fingerprint()}
+}
+
+type Deduplicator struct {
+	store Store
+}
+
+// New creates a deduplicator, a nil store means an empty MemoryStore,
+// so documents are deduplicated only across the results of the Check calls.
+func New(store Store) *Deduplicator {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+
+	return &Deduplicator{store: store}
+}
+
+// Check classifies documents of the results in order. New documents are saved to the store,
+// so a repeated document of a later result is a duplicate. Conflicting versions are not saved
+// and are reported on every run until resolved.
+func (d *Deduplicator) Check(ctx context.Context, results ...*onec.Result) (*Report, error) {
+	report := &Report{Findings: []Finding{}}
+
+	for i, result := range results {
+		for j := range result.PaymentDocuments {
+			f, err := d.check(ctx, &result.PaymentDocuments[j])
+			if err != nil {
+				return nil, fmt.Errorf("document %d of result %d: %w", j, i, err)
+			}
+
+			f.Result, f.Index = i, j
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	return report, nil
+}
+
+func (d *Deduplicator) check(ctx context.Context, doc *onec.PaymentDocument) (Finding, error) {
+	record := RecordOf(doc)
+	f := Finding{
+		Document:    doc,
+		Status:      StatusNew,
+		Identity:    record.Identity,
+		Fingerprint: record.Fingerprint,
+	}
+
+	known, err := d.store.Fingerprints(ctx, f.Identity)
+	if err != nil {
+		return f, fmt.Errorf("unable to get known fingerprints: %w", err)
+	}
+
+	switch {
+	case len(known) == 0:
+		if err := d.store.Save(ctx, record); err != nil {
+			return f, fmt.Errorf("unable to save fingerprint: %w", err)
+		}
+	case slices.Contains(known, f.Fingerprint):
+		f.Status = StatusDuplicate
+	default:
+		f.Status = StatusConflict
+		f.Known = known
+	}
+
+	return f, nil
+}
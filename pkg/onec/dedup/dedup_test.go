@@ -0,0 +1,110 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+type DedupTestSuite struct {
+	suite.Suite
+}
+
+func document(number string, day int, summ, purpose string) onec.PaymentDocument {
+	date := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+
+	return onec.PaymentDocument{
+		Number:          number,
+		Data:            &date,
+		Summ:            money.MustParse(summ),
+		PayerAccount:    "40702810001234567890",
+		ReceiverAccount: "40702810009876543210",
+		PaymentPurpose:  purpose,
+	}
+}
+
+func (suite *DedupTestSuite) TestFingerprint() {
+	a := document("1", 10, "100", "Оплата по счету 5")
+	b := document(" 1 ", 10, "100", "Оплата  по счету\n5 ")
+	c := document("1", 10, "100.01", "Оплата по счету 5")
+
+	suite.Equal(a.Fingerprint(), b.Fingerprint())
+	suite.Len(a.Fingerprint(), 64)
+	suite.Equal(a.IdentityKey(), c.IdentityKey())
+	suite.NotEqual(a.Fingerprint(), c.Fingerprint())
+}
+
+func (suite *DedupTestSuite) TestCheckResults() {
+	january := &onec.Result{PaymentDocuments: []onec.PaymentDocument{
+		document("1", 10, "100", "Оплата по счету 5"),
+		document("2", 20, "200", "Оплата по счету 6"),
+	}}
+	overlap := &onec.Result{PaymentDocuments: []onec.PaymentDocument{
+		document("2", 20, "200", "Оплата по счету 6"),
+		document("1", 10, "150", "Оплата по счету 5"),
+		document("3", 25, "300", "Оплата по счету 7"),
+	}}
+
+	report, err := New(nil).Check(context.Background(), january, overlap)
+	suite.Require().NoError(err)
+
+	statuses := make([]Status, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		statuses = append(statuses, f.Status)
+	}
+
+	suite.Equal([]Status{
+		StatusNew, StatusNew, StatusDuplicate, StatusConflict, StatusNew,
+	}, statuses)
+
+	conflict := report.Conflicts()[0]
+	suite.Equal(1, conflict.Result)
+	suite.Equal(1, conflict.Index)
+	suite.Equal([]string{january.PaymentDocuments[0].Fingerprint()}, conflict.Known)
+	suite.Len(report.Duplicates(), 1)
+	suite.Len(report.Documents(), 3)
+}
+
+func (suite *DedupTestSuite) TestCheckKnownRecords() {
+	imported := document("1", 10, "100", "Оплата по счету 5")
+	store := NewMemoryStore(RecordOf(&imported))
+
+	result := &onec.Result{PaymentDocuments: []onec.PaymentDocument{
+		document("1", 10, "100", "Оплата по счету 5"),
+		document("2", 11, "100", "Оплата по счету 5"),
+	}}
+
+	report, err := New(store).Check(context.Background(), result)
+	suite.Require().NoError(err)
+
+	suite.Equal(StatusDuplicate, report.Findings[0].Status)
+	suite.Equal(StatusNew, report.Findings[1].Status)
+	suite.Len(store.Records(), 2)
+}
+
+type failingStore struct{}
+
+func (failingStore) Fingerprints(context.Context, string) ([]string, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingStore) Save(context.Context, Record) error {
+	return nil
+}
+
+func (suite *DedupTestSuite) TestStoreError() {
+	result := &onec.Result{PaymentDocuments: []onec.PaymentDocument{document("1", 10, "100", "")}}
+
+	_, err := New(failingStore{}).Check(context.Background(), result)
+	suite.ErrorContains(err, "connection refused")
+}
+
+func TestDedupTestSuite(t *testing.T) {
+	suite.Run(t, new(DedupTestSuite))
+}
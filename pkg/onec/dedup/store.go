@@ -0,0 +1,85 @@
+package dedup
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Record is a known document: its identity key and content fingerprint.
+type Record struct {
+	Identity    string `json:"identity"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Store keeps fingerprints of already processed documents, e.g. in a database table
+// next to the imported payment documents.
+type Store interface {
+	// Fingerprints returns fingerprints of known documents with the identity key.
+	Fingerprints(ctx context.Context, identity string) ([]string, error)
+	Save(ctx context.Context, record Record) error
+}
+
+// MemoryStore is a Store kept in memory, safe for concurrent use.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	known map[string][]string
+}
+
+// NewMemoryStore creates a store seeded with known records.
+func NewMemoryStore(known ...Record) *MemoryStore {
+	s := &MemoryStore{known: make(map[string][]string, len(known))}
+
+	for _, r := range known {
+		s.add(r)
+	}
+
+	return s
+}
+
+func (s *MemoryStore) Fingerprints(_ context.Context, identity string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.known[identity]), nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.add(record)
+
+	return nil
+}
+
+// Records returns all known records, e.g. to persist them after an in-memory run.
+func (s *MemoryStore) Records() []Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]Record, 0, len(s.known))
+
+	for identity, fingerprints := range s.known {
+		for _, fp := range fingerprints {
+			res = append(res, Record{Identity: identity, Fingerprint: fp})
+		}
+	}
+
+	slices.SortFunc(res, func(a, b Record) int {
+		if a.Identity != b.Identity {
+			return strings.Compare(a.Identity, b.Identity)
+		}
+
+		return strings.Compare(a.Fingerprint, b.Fingerprint)
+	})
+
+	return res
+}
+
+func (s *MemoryStore) add(r Record) {
+	if !slices.Contains(s.known[r.Identity], r.Fingerprint) {
+		s.known[r.Identity] = append(s.known[r.Identity], r.Fingerprint)
+	}
+}
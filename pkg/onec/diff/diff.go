@@ -0,0 +1,374 @@
+// Package diff compares two parse results of the same statement, e.g. an upload and the statement
+// re-issued by the bank: payment documents are matched by onec.PaymentDocument.IdentityKey and
+// compared field by field, balances are compared per account.
+package diff
+
+import (
+	"cmp"
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+type Kind string
+
+const (
+	Added    Kind = "added"
+	Removed  Kind = "removed"
+	Modified Kind = "modified"
+)
+
+// FieldChange is a changed key of the document section, Field is the 1C key (Сумма, НазначениеПлатежа...).
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// DocumentChange is an added, removed or modified document, Old is nil for added documents
+// and New is nil for removed ones.
+type DocumentChange struct {
+	Kind     Kind                  `json:"kind"`
+	Identity string                `json:"identity"`
+	Old      *onec.PaymentDocument `json:"old,omitempty"`
+	New      *onec.PaymentDocument `json:"new,omitempty"`
+	Fields   []FieldChange         `json:"fields,omitempty"`
+}
+
+// Document returns the new document or the removed one.
+func (c *DocumentChange) Document() *onec.PaymentDocument {
+	if c.New != nil {
+		return c.New
+	}
+
+	return c.Old
+}
+
+// Totals are the balances of an account over all its statements: the initial balance of the first
+// statement, the sums of income and write-off and the final balance of the last statement.
+type Totals struct {
+	StartDate      *time.Time  `json:"start_date,omitempty"`
+	EndDate        *time.Time  `json:"end_date,omitempty"`
+	InitialBalance money.Money `json:"initial_balance"`
+	Income         money.Money `json:"income"`
+	WriteOff       money.Money `json:"write_off"`
+	FinalBalance   money.Money `json:"final_balance"`
+}
+
+// BalanceDelta compares the totals of an account, deltas are New minus Old.
+// Old or New is nil when the account is missing in that result.
+type BalanceDelta struct {
+	Account        string      `json:"account"`
+	Old            *Totals     `json:"old,omitempty"`
+	New            *Totals     `json:"new,omitempty"`
+	InitialBalance money.Money `json:"initial_balance_delta"`
+	Income         money.Money `json:"income_delta"`
+	WriteOff       money.Money `json:"write_off_delta"`
+	FinalBalance   money.Money `json:"final_balance_delta"`
+}
+
+// IsChanged reports whether the account appeared, disappeared or any of its totals changed.
+func (b *BalanceDelta) IsChanged() bool {
+	return b.Old == nil || b.New == nil ||
+		!b.InitialBalance.IsZero() || !b.Income.IsZero() || !b.WriteOff.IsZero() || !b.FinalBalance.IsZero()
+}
+
+// Diff lists document changes in the order of the new result, removed documents follow in the order
+// of the old one, and balance deltas of all accounts sorted by account.
+type Diff struct {
+	Documents []DocumentChange `json:"documents"`
+	Balances  []BalanceDelta   `json:"balances"`
+}
+
+// IsEmpty reports whether the results have the same documents and balances.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Documents) == 0 &&
+		!slices.ContainsFunc(d.Balances, func(b BalanceDelta) bool { return b.IsChanged() })
+}
+
+func (d *Diff) Added() []DocumentChange {
+	return d.filter(Added)
+}
+
+func (d *Diff) Removed() []DocumentChange {
+	return d.filter(Removed)
+}
+
+func (d *Diff) Modified() []DocumentChange {
+	return d.filter(Modified)
+}
+
+func (d *Diff) filter(kind Kind) []DocumentChange {
+	var res []DocumentChange
+
+	for _, c := range d.Documents {
+		if c.Kind == kind {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// Compare compares the old and the new results. Documents with the same identity are paired
+// unchanged ones first, the rest in file order.
+func Compare(oldResult, newResult *onec.Result) *Diff {
+	return &Diff{
+		Documents: compareDocuments(oldResult.PaymentDocuments, newResult.PaymentDocuments),
+		Balances:  compareBalances(oldResult.Remainings, newResult.Remainings),
+	}
+}
+
+func compareDocuments(oldDocs, newDocs []onec.PaymentDocument) []DocumentChange {
+	olds := make(map[string][]*onec.PaymentDocument, len(oldDocs))
+	for i := range oldDocs {
+		key := oldDocs[i].IdentityKey()
+		olds[key] = append(olds[key], &oldDocs[i])
+	}
+
+	news := make(map[string][]*onec.PaymentDocument, len(newDocs))
+	for i := range newDocs {
+		key := newDocs[i].IdentityKey()
+		news[key] = append(news[key], &newDocs[i])
+	}
+
+	// одинаковые документы исключаются из сравнения первыми
+	for key, docs := range news {
+		olds[key], news[key] = dropEqual(olds[key], docs)
+	}
+
+	var res []DocumentChange
+
+	for i := range newDocs {
+		doc := &newDocs[i]
+		key := doc.IdentityKey()
+
+		if !slices.Contains(news[key], doc) {
+			continue
+		}
+
+		if len(olds[key]) == 0 {
+			res = append(res, DocumentChange{Kind: Added, Identity: key, New: doc})
+
+			continue
+		}
+
+		old := olds[key][0]
+		olds[key] = olds[key][1:]
+
+		res = append(res, DocumentChange{
+			Kind:     Modified,
+			Identity: key,
+			Old:      old,
+			New:      doc,
+			Fields:   Fields(old, doc),
+		})
+	}
+
+	for i := range oldDocs {
+		doc := &oldDocs[i]
+		if key := doc.IdentityKey(); slices.Contains(olds[key], doc) {
+			res = append(res, DocumentChange{Kind: Removed, Identity: key, Old: doc})
+		}
+	}
+
+	return res
+}
+
+// dropEqual removes pairs of documents without changed fields, Fingerprint is not enough:
+// it skips INN, KPP, dates of writing off and income and the banks of the parties.
+func dropEqual(
+	olds, news []*onec.PaymentDocument,
+) ([]*onec.PaymentDocument, []*onec.PaymentDocument) {
+	if len(olds) == 0 {
+		return olds, news
+	}
+
+	// поля каждого документа форматируются один раз, а не для каждой пары
+	olds = slices.Clone(olds)
+
+	oldValues := make([][]string, len(olds))
+	for i, old := range olds {
+		oldValues[i] = values(old)
+	}
+
+	var rest []*onec.PaymentDocument
+
+	for _, doc := range news {
+		docValues := values(doc)
+
+		i := slices.IndexFunc(
+			oldValues,
+			func(v []string) bool { return slices.Equal(v, docValues) },
+		)
+		if i < 0 {
+			rest = append(rest, doc)
+
+			continue
+		}
+
+		olds = slices.Delete(olds, i, i+1)
+		oldValues = slices.Delete(oldValues, i, i+1)
+	}
+
+	return olds, rest
+}
+
+// sectionField is a field of onec.PaymentDocument with a key of the document section.
+type sectionField struct {
+	index int
+	key   string
+}
+
+// sectionFields are looked up once, reflection over the tags is not repeated for every document.
+var sectionFields = func() []sectionField {
+	var res []sectionField
+
+	typ := reflect.TypeFor[onec.PaymentDocument]()
+	for i := range typ.NumField() {
+		key, _, _ := strings.Cut(typ.Field(i).Tag.Get("mapstructure"), ",")
+		if key != "" && key != "-" {
+			res = append(res, sectionField{index: i, key: key})
+		}
+	}
+
+	return res
+}()
+
+// Fields compares the keys of the document sections, keys missing in both documents are skipped.
+func Fields(oldDoc, newDoc *onec.PaymentDocument) []FieldChange {
+	var res []FieldChange
+
+	oldValues, newValues := values(oldDoc), values(newDoc)
+
+	for i, f := range sectionFields {
+		if oldValues[i] != newValues[i] {
+			res = append(res, FieldChange{Field: f.key, Old: oldValues[i], New: newValues[i]})
+		}
+	}
+
+	return res
+}
+
+// values formats the section fields of the document in the order of sectionFields.
+func values(doc *onec.PaymentDocument) []string {
+	val := reflect.ValueOf(doc).Elem()
+
+	res := make([]string, len(sectionFields))
+	for i, f := range sectionFields {
+		res[i] = format(val.Field(f.index))
+	}
+
+	return res
+}
+
+func format(field reflect.Value) string {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return ""
+		}
+
+		field = field.Elem()
+	}
+
+	if m, ok := field.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err == nil {
+			return string(text)
+		}
+	}
+
+	return strings.TrimSpace(fmt.Sprint(field.Interface()))
+}
+
+func compareBalances(oldBalances, newBalances []onec.AccountBalance) []BalanceDelta {
+	olds, news := totals(oldBalances), totals(newBalances)
+
+	accounts := make([]string, 0, len(olds)+len(news))
+	for account := range olds {
+		accounts = append(accounts, account)
+	}
+
+	for account := range news {
+		if _, ok := olds[account]; !ok {
+			accounts = append(accounts, account)
+		}
+	}
+
+	slices.Sort(accounts)
+
+	res := make([]BalanceDelta, 0, len(accounts))
+
+	for _, account := range accounts {
+		delta := BalanceDelta{Account: account, Old: olds[account], New: news[account]}
+
+		var o, n Totals
+		if delta.Old != nil {
+			o = *delta.Old
+		}
+
+		if delta.New != nil {
+			n = *delta.New
+		}
+
+		delta.InitialBalance = n.InitialBalance.Sub(o.InitialBalance)
+		delta.Income = n.Income.Sub(o.Income)
+		delta.WriteOff = n.WriteOff.Sub(o.WriteOff)
+		delta.FinalBalance = n.FinalBalance.Sub(o.FinalBalance)
+
+		res = append(res, delta)
+	}
+
+	return res
+}
+
+func totals(balances []onec.AccountBalance) map[string]*Totals {
+	byAccount := make(map[string][]onec.AccountBalance)
+	for _, b := range balances {
+		byAccount[b.Account] = append(byAccount[b.Account], b)
+	}
+
+	res := make(map[string]*Totals, len(byAccount))
+
+	for account, list := range byAccount {
+		slices.SortStableFunc(list, func(a, b onec.AccountBalance) int {
+			return compareDates(a.StartDate, b.StartDate)
+		})
+
+		t := &Totals{
+			StartDate:      list[0].StartDate,
+			EndDate:        list[len(list)-1].EndDate,
+			InitialBalance: list[0].InitialBalance,
+			FinalBalance:   list[len(list)-1].FinalBalance,
+		}
+
+		for _, b := range list {
+			t.Income = t.Income.Add(b.Income)
+			t.WriteOff = t.WriteOff.Add(b.WriteOff)
+		}
+
+		res[account] = t
+	}
+
+	return res
+}
+
+// compareDates orders nil dates first.
+func compareDates(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return cmp.Compare(a.UnixNano(), b.UnixNano())
+	}
+}
@@ -0,0 +1,245 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+const (
+	account      = "40702810000000001234"
+	counterparty = "40702810000000005678"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func document(number, day, summ, purpose string) onec.PaymentDocument {
+	return onec.PaymentDocument{
+		DocumentType:    "Платежное поручение",
+		Number:          number,
+		DataStr:         day + ".01.2024",
+		Data:            onec.ParseDate(day + ".01.2024"),
+		Summ:            money.MustParse(summ),
+		PayerAccount:    account,
+		ReceiverAccount: counterparty,
+		PaymentPurpose:  purpose,
+	}
+}
+
+func balance(start, end, initial, income, writeOff, final string) onec.AccountBalance {
+	return onec.AccountBalance{
+		Account:        account,
+		StartDateStr:   start,
+		StartDate:      onec.ParseDate(start),
+		EndDateStr:     end,
+		EndDate:        onec.ParseDate(end),
+		InitialBalance: money.MustParse(initial),
+		Income:         money.MustParse(income),
+		WriteOff:       money.MustParse(writeOff),
+		FinalBalance:   money.MustParse(final),
+	}
+}
+
+func (suite *DiffTestSuite) TestEqual() {
+	result := &onec.Result{
+		Remainings: []onec.AccountBalance{
+			balance("01.01.2024", "31.01.2024", "10", "0", "3", "7"),
+		},
+		PaymentDocuments: []onec.PaymentDocument{document("1", "15", "3.00", "оплата")},
+	}
+
+	d := Compare(result, result)
+
+	suite.True(d.IsEmpty())
+	suite.Empty(d.Documents)
+	suite.Len(d.Balances, 1)
+	suite.False(d.Balances[0].IsChanged())
+}
+
+func (suite *DiffTestSuite) TestCompare() {
+	oldResult := &onec.Result{
+		Remainings: []onec.AccountBalance{balance("01.01.2024", "31.01.2024", "10", "0", "8", "2")},
+		PaymentDocuments: []onec.PaymentDocument{
+			document("1", "15", "3.00", "оплата по счету 1"),
+			document("2", "16", "4.00", "оплата по счету 2"),
+			document("3", "17", "1.00", "комиссия"),
+		},
+	}
+
+	changed := document("2", "16", "4.50", "оплата по счету 2, в т.ч. НДС")
+	changed.PayerKPP = utils.ToPtr("770701001")
+
+	newResult := &onec.Result{
+		Remainings: []onec.AccountBalance{
+			balance("01.01.2024", "15.01.2024", "10", "0", "3", "7"),
+			balance("16.01.2024", "31.01.2024", "7", "0", "6.5", "0.5"),
+		},
+		PaymentDocuments: []onec.PaymentDocument{
+			document("1", "15", "3.00", "оплата по счету 1"),
+			changed,
+			document("4", "18", "2.00", "оплата по счету 4"),
+		},
+	}
+
+	d := Compare(oldResult, newResult)
+	suite.False(d.IsEmpty())
+
+	suite.Require().Len(d.Documents, 3)
+	suite.Equal(Modified, d.Documents[0].Kind)
+	suite.Equal(
+		[]FieldChange{
+			{Field: "Сумма", Old: "4.00", New: "4.50"},
+			{Field: "ПлательщикКПП", Old: "", New: "770701001"},
+			{
+				Field: "НазначениеПлатежа",
+				Old:   "оплата по счету 2",
+				New:   "оплата по счету 2, в т.ч. НДС",
+			},
+		},
+		d.Documents[0].Fields,
+	)
+	suite.Equal(Added, d.Documents[1].Kind)
+	suite.Equal("4", d.Documents[1].Document().Number)
+	suite.Equal(Removed, d.Documents[2].Kind)
+	suite.Equal("3", d.Documents[2].Document().Number)
+
+	suite.Len(d.Added(), 1)
+	suite.Len(d.Removed(), 1)
+	suite.Len(d.Modified(), 1)
+
+	// два остатка нового файла складываются в итоги счета
+	suite.Require().Len(d.Balances, 1)
+	delta := d.Balances[0]
+	suite.True(delta.IsChanged())
+	suite.Equal(money.MustParse("9.5"), delta.New.WriteOff)
+	suite.Equal(money.MustParse("1.5"), delta.WriteOff)
+	suite.Equal(money.MustParse("-1.5"), delta.FinalBalance)
+	suite.True(delta.InitialBalance.IsZero())
+}
+
+func (suite *DiffTestSuite) TestDuplicateIdentity() {
+	// одинаковые номер, дата и счета у двух документов: неизмененный документ в паре с самим собой
+	oldResult := &onec.Result{PaymentDocuments: []onec.PaymentDocument{
+		document("1", "15", "1.00", "первый"),
+		document("1", "15", "2.00", "второй"),
+	}}
+	newResult := &onec.Result{PaymentDocuments: []onec.PaymentDocument{
+		document("1", "15", "2.00", "второй"),
+		document("1", "15", "1.50", "первый"),
+	}}
+
+	d := Compare(oldResult, newResult)
+
+	suite.Require().Len(d.Documents, 1)
+	suite.Equal(Modified, d.Documents[0].Kind)
+	suite.Equal([]FieldChange{{Field: "Сумма", Old: "1.00", New: "1.50"}}, d.Documents[0].Fields)
+}
+
+func (suite *DiffTestSuite) TestFieldsOutsideFingerprint() {
+	oldDoc := document("1", "15", "3.00", "оплата")
+	oldDoc.ReceiverINN = "7707083893"
+
+	newDoc := oldDoc
+	newDoc.ReceiverINN = "7707083894"
+	newDoc.WrittenOffDateStr = "16.01.2024"
+
+	d := Compare(
+		&onec.Result{PaymentDocuments: []onec.PaymentDocument{oldDoc}},
+		&onec.Result{PaymentDocuments: []onec.PaymentDocument{newDoc}},
+	)
+
+	suite.Require().Len(d.Documents, 1)
+	suite.Equal(Modified, d.Documents[0].Kind)
+	suite.ElementsMatch([]FieldChange{
+		{Field: "ПолучательИНН", Old: "7707083893", New: "7707083894"},
+		{Field: "ДатаСписано", Old: "", New: "16.01.2024"},
+	}, d.Documents[0].Fields)
+}
+
+func (suite *DiffTestSuite) TestAccounts() {
+	other := balance("01.01.2024", "31.01.2024", "5", "0", "0", "5")
+	other.Account = counterparty
+
+	d := Compare(
+		&onec.Result{
+			Remainings: []onec.AccountBalance{
+				balance("01.01.2024", "31.01.2024", "1", "0", "0", "1"),
+			},
+		},
+		&onec.Result{Remainings: []onec.AccountBalance{other}},
+	)
+
+	suite.Require().Len(d.Balances, 2)
+	suite.Equal(account, d.Balances[0].Account)
+	suite.Nil(d.Balances[0].New)
+	suite.Equal(money.MustParse("-1"), d.Balances[0].FinalBalance)
+	suite.Equal(counterparty, d.Balances[1].Account)
+	suite.Nil(d.Balances[1].Old)
+	suite.Equal(money.MustParse("5"), d.Balances[1].FinalBalance)
+}
+
+func (suite *DiffTestSuite) TestWriteText() {
+	oldResult := &onec.Result{
+		Remainings: []onec.AccountBalance{
+			balance("01.01.2024", "31.01.2024", "10", "0", "3", "7"),
+		},
+		PaymentDocuments: []onec.PaymentDocument{document("1", "15", "3.00", "оплата")},
+	}
+	newResult := &onec.Result{
+		Remainings: []onec.AccountBalance{
+			balance("01.01.2024", "31.01.2024", "10", "0", "5", "5"),
+		},
+		PaymentDocuments: []onec.PaymentDocument{document("1", "15", "5.00", "оплата")},
+	}
+
+	var buf bytes.Buffer
+	suite.Require().NoError(Compare(oldResult, newResult).WriteText(&buf))
+
+	suite.Equal(
+		"documents: 0 added, 0 removed, 1 modified\n"+
+			"~ Платежное поручение №1 от 15.01.2024, 5.00: "+account+" -> "+counterparty+"\n"+
+			"    Сумма: \"3.00\" -> \"5.00\"\n"+
+			"~ account "+account+"\n"+
+			"    ВсегоСписано: 3.00 -> 5.00 (+2.00)\n"+
+			"    КонечныйОстаток: 7.00 -> 5.00 (-2.00)\n",
+		buf.String(),
+	)
+}
+
+func (suite *DiffTestSuite) TestWriteJSON() {
+	oldResult := &onec.Result{
+		PaymentDocuments: []onec.PaymentDocument{document("1", "15", "3.00", "оплата")},
+	}
+	newResult := &onec.Result{}
+
+	var buf bytes.Buffer
+	suite.Require().NoError(Compare(oldResult, newResult).WriteJSON(&buf))
+
+	var decoded struct {
+		Documents []struct {
+			Kind string `json:"kind"`
+			Old  struct {
+				Number string  `json:"number"`
+				Summ   float64 `json:"summ"`
+			} `json:"old"`
+		} `json:"documents"`
+	}
+
+	suite.Require().NoError(json.Unmarshal(buf.Bytes(), &decoded))
+	suite.Require().Len(decoded.Documents, 1)
+	suite.Equal("removed", decoded.Documents[0].Kind)
+	suite.Equal("1", decoded.Documents[0].Old.Number)
+	suite.InDelta(3.0, decoded.Documents[0].Old.Summ, 0)
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+)
+
+var signs = map[Kind]string{Added: "+", Removed: "-", Modified: "~"}
+
+// WriteJSON writes the diff as an indented JSON document.
+func (d *Diff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(d)
+}
+
+// WriteText writes the diff in a diff(1) like form: "+" added, "-" removed and "~" modified
+// documents with their changed keys, then the accounts whose balances changed.
+func (d *Diff) WriteText(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, "documents: %d added, %d removed, %d modified\n",
+		len(d.Added()), len(d.Removed()), len(d.Modified()))
+
+	for i := range d.Documents {
+		c := &d.Documents[i]
+		doc := c.Document()
+
+		fmt.Fprintf(
+			buf,
+			"%s %s №%s от %s, %s: %s -> %s\n",
+			signs[c.Kind],
+			doc.DocumentType,
+			doc.Number,
+			doc.DataStr,
+			doc.Summ,
+			doc.PayerAccount,
+			doc.ReceiverAccount,
+		)
+
+		for _, f := range c.Fields {
+			fmt.Fprintf(buf, "    %s: %q -> %q\n", f.Field, f.Old, f.New)
+		}
+	}
+
+	for i := range d.Balances {
+		b := &d.Balances[i]
+		if !b.IsChanged() {
+			continue
+		}
+
+		switch {
+		case b.Old == nil:
+			fmt.Fprintf(buf, "+ account %s: final balance %s\n", b.Account, b.New.FinalBalance)
+		case b.New == nil:
+			fmt.Fprintf(buf, "- account %s: final balance %s\n", b.Account, b.Old.FinalBalance)
+		default:
+			fmt.Fprintf(buf, "~ account %s\n", b.Account)
+			writeDelta(buf, "НачальныйОстаток", b.Old.InitialBalance, b.New.InitialBalance)
+			writeDelta(buf, "ВсегоПоступило", b.Old.Income, b.New.Income)
+			writeDelta(buf, "ВсегоСписано", b.Old.WriteOff, b.New.WriteOff)
+			writeDelta(buf, "КонечныйОстаток", b.Old.FinalBalance, b.New.FinalBalance)
+		}
+	}
+
+	return buf.Flush()
+}
+
+func writeDelta(buf *bufio.Writer, key string, oldValue, newValue money.Money) {
+	if oldValue == newValue {
+		return
+	}
+
+	delta := newValue.Sub(oldValue)
+
+	sign := ""
+	if delta.IsPositive() {
+		sign = "+"
+	}
+
+	fmt.Fprintf(buf, "    %s: %s -> %s (%s%s)\n", key, oldValue, newValue, sign, delta)
+}
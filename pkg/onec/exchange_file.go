@@ -1,26 +1,40 @@
 package onec
 
 import (
+	"slices"
 	"strings"
 	"time"
 
 	pb "github.com/SOTBI-LLC/sotbi.lib/pkg/api/onec"
 )
 
+// Encodings an exchange file can be read in, see ExchangeFile.DetectedEncoding.
+const (
+	EncodingWindows1251 = "windows-1251"
+	EncodingCP866       = "cp866"
+	EncodingUTF8        = "utf-8"
+)
+
+// dateLayout формат дат файла обмена.
+const dateLayout = "02.01.2006"
+
+// ExchangeFile is the header of an exchange file, DetectedEncoding is the encoding the file
+// was actually decoded with, it may differ from the declared Encoding (Кодировка=Windows|DOS).
 type ExchangeFile struct {
-	ID             uint64     `json:"id"`
-	FormatVer      string     `json:"format_ver"             mapstructure:"ВерсияФормата"`
-	Encoding       string     `json:"encoding"               mapstructure:"Кодировка"`
-	Sender         string     `json:"sender"                 mapstructure:"Отправитель"`
-	Receiver       string     `json:"receiver"               mapstructure:"Получатель"`
-	CreatedDateStr string     `json:"-"                      mapstructure:"ДатаСоздания"`
-	CreatedTimeStr string     `json:"-"                      mapstructure:"ВремяСоздания"`
-	CreatedDate    *time.Time `json:"created_date,omitempty" mapstructure:"-"`
-	StartDateStr   string     `json:"-"                      mapstructure:"ДатаНачала"`
-	StartDate      *time.Time `json:"start_date,omitempty"   mapstructure:"-"`
-	EndDateStr     string     `json:"-"                      mapstructure:"ДатаКонца"`
-	EndDate        *time.Time `json:"end_date,omitempty"     mapstructure:"-"`
-	Account        []string   `json:"account,omitempty"      mapstructure:"РасчСчет,omitempty"`
+	ID               uint64     `json:"id"`
+	FormatVer        string     `json:"format_ver"                  mapstructure:"ВерсияФормата"`
+	Encoding         string     `json:"encoding"                    mapstructure:"Кодировка"`
+	Sender           string     `json:"sender"                      mapstructure:"Отправитель"`
+	Receiver         string     `json:"receiver"                    mapstructure:"Получатель"`
+	CreatedDateStr   string     `json:"-"                           mapstructure:"ДатаСоздания"`
+	CreatedTimeStr   string     `json:"-"                           mapstructure:"ВремяСоздания"`
+	CreatedDate      *time.Time `json:"created_date,omitempty"      mapstructure:"-"`
+	StartDateStr     string     `json:"-"                           mapstructure:"ДатаНачала"`
+	StartDate        *time.Time `json:"start_date,omitempty"        mapstructure:"-"`
+	EndDateStr       string     `json:"-"                           mapstructure:"ДатаКонца"`
+	EndDate          *time.Time `json:"end_date,omitempty"          mapstructure:"-"`
+	Account          []string   `json:"account,omitempty"           mapstructure:"РасчСчет,omitempty"`
+	DetectedEncoding string     `json:"detected_encoding,omitempty" mapstructure:"-"`
 }
 
 func (f *ExchangeFile) ToPB(request *pb.ParseRequest) *pb.ParseResponse {
@@ -52,3 +66,50 @@ func (f *ExchangeFile) ToPB(request *pb.ParseRequest) *pb.ParseResponse {
 		},
 	}
 }
+
+// SetPeriod fills ДатаНачала and ДатаКонца the way the exchange file parser does,
+// the end date covers the whole day in Moscow time.
+func (f *ExchangeFile) SetPeriod(start, end time.Time) {
+	f.StartDateStr, f.StartDate = periodStart(start)
+	f.EndDateStr, f.EndDate = periodEnd(end)
+}
+
+// NewStatementHeader builds the header for a statement converted from another format:
+// the period covers all balances and Account lists their accounts in order of appearance.
+func NewStatementHeader(formatVer string, balances []AccountBalance) ExchangeFile {
+	f := ExchangeFile{FormatVer: formatVer, Account: []string{}}
+
+	var start, end *time.Time
+
+	for _, b := range balances {
+		if !slices.Contains(f.Account, b.Account) {
+			f.Account = append(f.Account, b.Account)
+		}
+
+		if b.StartDate != nil && (start == nil || b.StartDate.Before(*start)) {
+			start = b.StartDate
+		}
+
+		if b.EndDate != nil && (end == nil || b.EndDate.After(*end)) {
+			end = b.EndDate
+		}
+	}
+
+	if start != nil && end != nil {
+		f.SetPeriod(*start, *end)
+	}
+
+	return f
+}
+
+func periodStart(t time.Time) (string, *time.Time) {
+	str := t.Format(dateLayout)
+
+	return str, ParseDate(str)
+}
+
+func periodEnd(t time.Time) (string, *time.Time) {
+	str := t.Format(dateLayout)
+
+	return str, ParseDateTime(str + " 23:59:59")
+}
@@ -0,0 +1,184 @@
+// Package ids provides onec.IDAllocator implementations: a Sonyflake generator, a Postgres sequence,
+// an in-memory counter and Block, which reserves ids of another allocator in blocks.
+package ids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+var ErrCount = errors.New("number of ids must be positive")
+
+var (
+	_ onec.IDAllocator = (*Sonyflake)(nil)
+	_ onec.IDAllocator = (*Sequence)(nil)
+	_ onec.IDAllocator = (*Counter)(nil)
+	_ onec.IDAllocator = (*Block)(nil)
+	_ onec.IDAllocator = Func(nil)
+)
+
+// Func adapts a function returning a single id, e.g. a former next() callback of the parsers.
+type Func func(ctx context.Context) (uint64, error)
+
+func (f Func) Allocate(ctx context.Context, n int) ([]uint64, error) {
+	if n <= 0 {
+		return nil, ErrCount
+	}
+
+	res := make([]uint64, n)
+
+	for i := range res {
+		id, err := f(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = id
+	}
+
+	return res, nil
+}
+
+// Sonyflake allocates ids of utils.Sonyflake, ids are generated locally and need no blocks.
+type Sonyflake struct {
+	flake *utils.Sonyflake
+}
+
+func NewSonyflake(flake *utils.Sonyflake) *Sonyflake {
+	return &Sonyflake{flake: flake}
+}
+
+func (s *Sonyflake) Allocate(ctx context.Context, n int) ([]uint64, error) {
+	return Func(func(context.Context) (uint64, error) {
+		return s.flake.NextID()
+	}).Allocate(ctx, n)
+}
+
+// Querier is satisfied by *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Sequence allocates ids of a Postgres sequence, n ids are taken in one query.
+// Wrap it into Block to reserve ids for several entities of a stream at once.
+type Sequence struct {
+	db   Querier
+	name string
+}
+
+// NewSequence creates an allocator of the sequence, name may be schema qualified.
+func NewSequence(db Querier, name string) *Sequence {
+	return &Sequence{db: db, name: name}
+}
+
+func (s *Sequence) Allocate(ctx context.Context, n int) ([]uint64, error) {
+	if n <= 0 {
+		return nil, ErrCount
+	}
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT nextval($1::regclass) FROM generate_series(1, $2)`,
+		s.name,
+		n,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w from sequence %s: %w", onec.ErrIDAllocation, s.name, err)
+	}
+
+	res, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("%w from sequence %s: %w", onec.ErrIDAllocation, s.name, err)
+	}
+
+	ids := make([]uint64, len(res))
+	for i, id := range res {
+		ids[i] = uint64(id) //nolint:gosec // значения последовательности положительные
+	}
+
+	return ids, nil
+}
+
+// Counter allocates sequential ids in memory, for tools and tests that need ids only to link entities.
+type Counter struct {
+	mu   sync.Mutex
+	last uint64
+}
+
+// NewCounter creates a counter whose first id is last+1.
+func NewCounter(last uint64) *Counter {
+	return &Counter{last: last}
+}
+
+func (c *Counter) Allocate(_ context.Context, n int) ([]uint64, error) {
+	if n <= 0 {
+		return nil, ErrCount
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make([]uint64, n)
+	for i := range res {
+		c.last++
+		res[i] = c.last
+	}
+
+	return res, nil
+}
+
+// Block reserves ids of the source allocator size at a time and hands them out from memory,
+// so a stream of entities costs one allocation per block. Ids left in the block when the
+// allocator is dropped are lost, which is fine for sequences and Sonyflake.
+type Block struct {
+	source onec.IDAllocator
+	size   int
+
+	mu       sync.Mutex
+	reserved []uint64
+}
+
+// NewBlock creates a block allocator, size less than 1 means 1.
+func NewBlock(source onec.IDAllocator, size int) *Block {
+	return &Block{source: source, size: max(size, 1)}
+}
+
+func (b *Block) Allocate(ctx context.Context, n int) ([]uint64, error) {
+	if n <= 0 {
+		return nil, ErrCount
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if missing := n - len(b.reserved); missing > 0 {
+		// резервируется не меньше блока, большой запрос получает недостающее целиком
+		more, err := b.source.Allocate(ctx, max(missing, b.size))
+		if err != nil {
+			return nil, err
+		}
+
+		b.reserved = append(b.reserved, more...)
+	}
+
+	if len(b.reserved) < n {
+		return nil, fmt.Errorf(
+			"%w: got %d ids instead of %d",
+			onec.ErrIDAllocation,
+			len(b.reserved),
+			n,
+		)
+	}
+
+	res := b.reserved[:n:n]
+	b.reserved = b.reserved[n:]
+
+	return res, nil
+}
@@ -0,0 +1,148 @@
+package ids
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+var errSource = errors.New("sequence is not available")
+
+// countingAllocator records the sizes of the requested blocks.
+type countingAllocator struct {
+	counter *Counter
+	calls   []int
+	err     error
+}
+
+func (a *countingAllocator) Allocate(ctx context.Context, n int) ([]uint64, error) {
+	a.calls = append(a.calls, n)
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	return a.counter.Allocate(ctx, n)
+}
+
+type IDsTestSuite struct {
+	suite.Suite
+
+	ctx context.Context //nolint:containedctx
+}
+
+func (suite *IDsTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+func (suite *IDsTestSuite) TestCounter() {
+	c := NewCounter(10)
+
+	res, err := c.Allocate(suite.ctx, 3)
+	suite.Require().NoError(err)
+	suite.Equal([]uint64{11, 12, 13}, res)
+
+	res, err = c.Allocate(suite.ctx, 1)
+	suite.Require().NoError(err)
+	suite.Equal([]uint64{14}, res)
+
+	_, err = c.Allocate(suite.ctx, 0)
+	suite.ErrorIs(err, ErrCount)
+}
+
+func (suite *IDsTestSuite) TestBlock() {
+	source := &countingAllocator{counter: NewCounter(0)}
+	b := NewBlock(source, 5)
+
+	var all []uint64
+
+	for range 7 {
+		res, err := b.Allocate(suite.ctx, 1)
+		suite.Require().NoError(err)
+
+		all = append(all, res...)
+	}
+
+	suite.Equal([]uint64{1, 2, 3, 4, 5, 6, 7}, all)
+	suite.Equal([]int{5, 5}, source.calls)
+
+	// большой запрос берёт недостающее одним вызовом
+	res, err := b.Allocate(suite.ctx, 10)
+	suite.Require().NoError(err)
+	suite.Equal([]uint64{8, 9, 10, 11, 12, 13, 14, 15, 16, 17}, res)
+	suite.Equal([]int{5, 5, 7}, source.calls)
+}
+
+func (suite *IDsTestSuite) TestBlockError() {
+	source := &countingAllocator{counter: NewCounter(0), err: errSource}
+
+	_, err := NewBlock(source, 100).Allocate(suite.ctx, 1)
+	suite.ErrorIs(err, errSource)
+
+	// источник вернул меньше, чем просили
+	b := NewBlock(allocatorFunc(func(context.Context, int) ([]uint64, error) {
+		return []uint64{1}, nil
+	}), 10)
+
+	_, err = b.Allocate(suite.ctx, 2)
+	suite.ErrorIs(err, onec.ErrIDAllocation)
+}
+
+func (suite *IDsTestSuite) TestFunc() {
+	var id uint64
+
+	f := Func(func(context.Context) (uint64, error) {
+		id += 2
+
+		return id, nil
+	})
+
+	res, err := f.Allocate(suite.ctx, 3)
+	suite.Require().NoError(err)
+	suite.Equal([]uint64{2, 4, 6}, res)
+
+	_, err = Func(func(context.Context) (uint64, error) {
+		return 0, errSource
+	}).Allocate(suite.ctx, 1)
+	suite.ErrorIs(err, errSource)
+}
+
+func (suite *IDsTestSuite) TestSonyflake() {
+	flake, err := utils.NewSonyflake(utils.SonyflakeConfig{MachineID: 1})
+	suite.Require().NoError(err)
+
+	res, err := NewSonyflake(flake).Allocate(suite.ctx, 3)
+	suite.Require().NoError(err)
+	suite.Require().Len(res, 3)
+	suite.Less(res[0], res[1])
+	suite.Less(res[1], res[2])
+}
+
+func (suite *IDsTestSuite) TestAssignIDs() {
+	result := &onec.Result{
+		Remainings:       make([]onec.AccountBalance, 2),
+		PaymentDocuments: make([]onec.PaymentDocument, 3),
+	}
+
+	suite.Require().NoError(result.AssignIDs(suite.ctx, NewCounter(0)))
+	suite.Equal(uint64(1), result.ExchangeFile.ID)
+	suite.Equal(uint64(2), result.Remainings[0].ID)
+	suite.Equal(uint64(1), result.Remainings[1].ExchangeFileID)
+	suite.Equal(uint64(4), result.PaymentDocuments[0].ID)
+	suite.Equal(uint64(6), result.PaymentDocuments[2].ID)
+}
+
+// allocatorFunc adapts a function to onec.IDAllocator.
+type allocatorFunc func(ctx context.Context, n int) ([]uint64, error)
+
+func (f allocatorFunc) Allocate(ctx context.Context, n int) ([]uint64, error) {
+	return f(ctx, n)
+}
+
+func TestIDsTestSuite(t *testing.T) {
+	suite.Run(t, new(IDsTestSuite))
+}
@@ -0,0 +1,443 @@
+// Package mt940 converts SWIFT MT940 customer statements into the onec model,
+// so they go through the same pipeline as 1CClientBankExchange files.
+package mt940
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/parser"
+)
+
+// FormatVer is the ВерсияФормата of results converted from MT940.
+const FormatVer = "MT940"
+
+const (
+	documentPayment = "Платежное поручение"
+	documentOrder   = "Банковский ордер"
+)
+
+var (
+	ErrNoStatement = errors.New("no MT940 statement found")
+	ErrField       = errors.New("invalid MT940 field")
+
+	// :61: ValueDate[EntryDate]Mark[FundsCode]Amount TransactionType Reference[//BankReference][\nDetails]
+	statementLine = regexp.MustCompile(
+		`(?s)^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d{0,2})([NFS][A-Z0-9]{3})([^/\n]*)(?://([^\n]*))?(?:\n(.*))?$`,
+	)
+	// :60F:, :62F: и т.п. Mark Date Currency Amount
+	balanceLine = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d{0,2})$`)
+	// подполя ?NN структурированного поля :86:
+	subfield = regexp.MustCompile(`\?(\d{2})`)
+)
+
+// Parser reads MT940 statements, Encoding forces one of onec.Encoding* instead of detecting it:
+// Russian banks send MT940 in Windows-1251 as well as in UTF-8.
+type Parser struct {
+	Encoding string
+}
+
+var _ onec.Parser = (*Parser)(nil)
+
+// Scan converts every statement (:20: block) into an AccountBalance and every :61: line
+// with its :86: details into a PaymentDocument, debits are written off the statement account
+// and credits are income to it.
+func (p *Parser) Scan(
+	ctx context.Context,
+	file io.Reader,
+	ids onec.IDAllocator,
+) (*onec.Result, error) {
+	decoded, encoding := parser.Decode(file, p.Encoding)
+
+	fields, err := readFields(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := splitStatements(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &onec.Result{
+		Remainings:       make([]onec.AccountBalance, 0, len(statements)),
+		PaymentDocuments: []onec.PaymentDocument{},
+	}
+
+	for _, st := range statements {
+		result.Remainings = append(result.Remainings, st.balance)
+		result.PaymentDocuments = append(result.PaymentDocuments, st.documents...)
+	}
+
+	result.ExchangeFile = onec.NewStatementHeader(FormatVer, result.Remainings)
+	// Кодировка=Windows|DOS объявляют только файлы 1С, у выписки есть лишь фактическая кодировка
+	result.ExchangeFile.DetectedEncoding = encoding
+
+	if err = result.AssignIDs(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	return result.ProcessBalanceAndDocs(), nil
+}
+
+// field is a tag of the text block, its value keeps line breaks of continuation lines.
+type field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// readFields splits the text block {4:...-} or a bare statement into tagged fields,
+// basic and application header blocks are skipped.
+func readFields(file io.Reader) ([]field, error) {
+	scanner := bufio.NewScanner(file)
+
+	var (
+		fields []field
+		lineNo int
+	)
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r ")
+
+		if i := strings.Index(line, "{4:"); i >= 0 {
+			line = line[i+len("{4:"):]
+		}
+
+		switch {
+		case line == "" || line == "-}" || line == "-" || strings.HasPrefix(line, "{"):
+			continue
+		case strings.HasPrefix(line, ":"):
+			tag, value, ok := strings.Cut(line[1:], ":")
+			if !ok {
+				return nil, fmt.Errorf("%w: line %d: %q", ErrField, lineNo, line)
+			}
+
+			fields = append(fields, field{tag: tag, value: value, line: lineNo})
+		case len(fields) > 0:
+			fields[len(fields)-1].value += "\n" + line
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading data: %w", err)
+	}
+
+	return fields, nil
+}
+
+type statement struct {
+	balance   onec.AccountBalance
+	documents []onec.PaymentDocument
+	bik       string
+	opening   *time.Time
+	closing   *time.Time
+}
+
+func splitStatements(fields []field) ([]statement, error) {
+	var (
+		res     []statement
+		current *statement
+	)
+
+	for i, f := range fields {
+		if f.tag == "20" {
+			res = append(res, statement{})
+			current = &res[len(res)-1]
+
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("%w: line %d: :%s: before :20:", ErrField, f.line, f.tag)
+		}
+
+		var err error
+
+		switch f.tag {
+		case "25":
+			current.bik, current.balance.Account = parseAccount(f.value)
+		case "60F", "60M":
+			current.opening, current.balance.InitialBalance, err = parseBalance(f)
+		case "62F", "62M":
+			current.closing, current.balance.FinalBalance, err = parseBalance(f)
+		case "61":
+			info := ""
+			if i+1 < len(fields) && fields[i+1].tag == "86" {
+				info = fields[i+1].value
+			}
+
+			err = current.addDocument(f, info)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, ErrNoStatement
+	}
+
+	for i := range res {
+		res[i].setPeriod()
+	}
+
+	return res, nil
+}
+
+// parseAccount splits "BIC/account" of :25:, the BIC is kept only when it is a Russian BIK.
+func parseAccount(value string) (bik, account string) {
+	value = strings.TrimSpace(value)
+
+	i := strings.LastIndex(value, "/")
+	if i < 0 {
+		return "", value
+	}
+
+	if bik = value[:i]; len(bik) != 9 {
+		bik = ""
+	}
+
+	return bik, value[i+1:]
+}
+
+func parseBalance(f field) (*time.Time, money.Money, error) {
+	m := balanceLine.FindStringSubmatch(strings.TrimSpace(f.value))
+	if m == nil {
+		return nil, 0, fmt.Errorf("%w: line %d: :%s:%s", ErrField, f.line, f.tag, f.value)
+	}
+
+	date, err := parseDate(m[2])
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: line %d: %w", ErrField, f.line, err)
+	}
+
+	amount, err := money.Parse(m[4])
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: line %d: %w", ErrField, f.line, err)
+	}
+
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+
+	return &date, amount, nil
+}
+
+func (st *statement) addDocument(f field, info string) error {
+	m := statementLine.FindStringSubmatch(f.value)
+	if m == nil {
+		return fmt.Errorf("%w: line %d: :61:%s", ErrField, f.line, f.value)
+	}
+
+	valueDate, err := parseDate(m[1])
+	if err != nil {
+		return fmt.Errorf("%w: line %d: %w", ErrField, f.line, err)
+	}
+
+	booked := valueDate
+	if m[2] != "" {
+		if booked, err = entryDate(valueDate, m[2]); err != nil {
+			return fmt.Errorf("%w: line %d: %w", ErrField, f.line, err)
+		}
+	}
+
+	amount, err := money.Parse(m[5])
+	if err != nil {
+		return fmt.Errorf("%w: line %d: %w", ErrField, f.line, err)
+	}
+
+	// сторно кредита списывает со счета, сторно дебета зачисляет
+	debit := m[3] == "D" || m[3] == "RC"
+
+	doc := onec.PaymentDocument{
+		DocumentType:   documentType(m[6]),
+		Number:         reference(m[7], m[8]),
+		Summ:           amount,
+		PaymentPurpose: strings.TrimSpace(joinLines(m[9])),
+	}
+	doc.DataStr, doc.Data = documentDate(valueDate)
+
+	party := parseDetails(info)
+	if party.purpose != "" {
+		doc.PaymentPurpose = party.purpose
+	}
+
+	if debit {
+		st.balance.WriteOff = st.balance.WriteOff.Add(amount)
+		doc.WrittenOffDateStr, doc.WrittenOffDate = documentDate(booked)
+		doc.PayerAccount, doc.PayerCurrentAccount, doc.PayerBIK = st.balance.Account, st.balance.Account, st.bik
+		doc.ReceiverAccount, doc.ReceiverCurrentAccount = party.account, party.account
+		doc.Receiver, doc.ReceiverBIK = party.name, party.bik
+	} else {
+		st.balance.Income = st.balance.Income.Add(amount)
+		doc.IncomeDateStr, doc.IncomeDate = documentDate(booked)
+		doc.ReceiverAccount, doc.ReceiverCurrentAccount, doc.ReceiverBIK = st.balance.Account, st.balance.Account, st.bik
+		doc.PayerAccount, doc.PayerCurrentAccount = party.account, party.account
+		doc.Payer, doc.PayerBIK = party.name, party.bik
+	}
+
+	st.documents = append(st.documents, doc)
+
+	return nil
+}
+
+// setPeriod takes the statement period from the booking dates, the opening balance date
+// is the date of the previous closing balance in many banks and is used only without entries.
+func (st *statement) setPeriod() {
+	var start, end *time.Time
+
+	for _, d := range st.documents {
+		booked := d.WrittenOffDate
+		if booked == nil {
+			booked = d.IncomeDate
+		}
+
+		if start == nil || booked.Before(*start) {
+			start = booked
+		}
+
+		if end == nil || booked.After(*end) {
+			end = booked
+		}
+	}
+
+	if start == nil {
+		start = st.opening
+	}
+
+	if st.closing != nil && (end == nil || st.closing.After(*end)) {
+		end = st.closing
+	}
+
+	switch {
+	case start == nil && end == nil:
+		return
+	case start == nil:
+		start = end
+	case end == nil:
+		end = start
+	}
+
+	st.balance.SetPeriod(*start, *end)
+}
+
+func parseDate(yymmdd string) (time.Time, error) {
+	t, err := time.Parse("060102", yymmdd)
+	if err != nil {
+		return t, fmt.Errorf("invalid date %q", yymmdd)
+	}
+
+	return t, nil
+}
+
+// entryDate adds the year of the value date to the MMDD entry date, an entry may be booked
+// on the turn of the year before or after the value date.
+func entryDate(valueDate time.Time, mmdd string) (time.Time, error) {
+	t, err := time.Parse("0102", mmdd)
+	if err != nil {
+		return t, fmt.Errorf("invalid entry date %q", mmdd)
+	}
+
+	t = time.Date(valueDate.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch {
+	case t.Sub(valueDate) > 180*24*time.Hour:
+		t = t.AddDate(-1, 0, 0)
+	case valueDate.Sub(t) > 180*24*time.Hour:
+		t = t.AddDate(1, 0, 0)
+	}
+
+	return t, nil
+}
+
+func documentDate(t time.Time) (string, *time.Time) {
+	str := t.Format("02.01.2006")
+
+	return str, onec.ParseDate(str)
+}
+
+func documentType(transactionType string) string {
+	switch transactionType[1:] {
+	case "CHG", "COM", "INT":
+		return documentOrder
+	default:
+		return documentPayment
+	}
+}
+
+func reference(customer, bank string) string {
+	customer = strings.TrimSpace(customer)
+	if customer != "" && customer != "NONREF" {
+		return customer
+	}
+
+	return strings.TrimSpace(bank)
+}
+
+type counterparty struct {
+	purpose string
+	name    string
+	account string
+	bik     string
+}
+
+// parseDetails reads :86: either as ?NN subfields (?20-?29 and ?60-?63 purpose, ?30 bank code,
+// ?31 account, ?32-?33 name) or as free text of the purpose.
+// Continuation lines are joined with a space, a line starting a subfield is joined as is.
+func parseDetails(value string) counterparty {
+	value = joinLines(value)
+
+	idx := subfield.FindAllStringSubmatchIndex(value, -1)
+	if idx == nil {
+		return counterparty{purpose: strings.TrimSpace(value)}
+	}
+
+	var (
+		res     counterparty
+		purpose strings.Builder
+	)
+
+	for i, m := range idx {
+		end := len(value)
+		if i+1 < len(idx) {
+			end = idx[i+1][0]
+		}
+
+		code, text := value[m[2]:m[3]], value[m[1]:end]
+
+		switch {
+		case code >= "20" && code <= "29", code >= "60" && code <= "63":
+			purpose.WriteString(text)
+		case code == "30":
+			res.bik = strings.TrimSpace(text)
+		case code == "31":
+			res.account = strings.TrimSpace(text)
+		case code == "32", code == "33":
+			res.name += text
+		}
+	}
+
+	res.purpose = strings.TrimSpace(purpose.String())
+	res.name = strings.TrimSpace(res.name)
+
+	return res
+}
+
+// joinLines joins the lines of a multiline field, words are not split between lines.
+func joinLines(value string) string {
+	// перенос перед подполем ?NN только отделяет подполя
+	value = strings.ReplaceAll(value, "\n?", "?")
+
+	return strings.ReplaceAll(value, "\n", " ")
+}
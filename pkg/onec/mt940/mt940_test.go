@@ -0,0 +1,137 @@
+package mt940
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+)
+
+type MT940TestSuite struct {
+	suite.Suite
+}
+
+func (suite *MT940TestSuite) TestScan() {
+	file, err := os.Open("fixtures/statement.sta")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	result, err := (&Parser{}).Scan(context.Background(), file, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	header := result.ExchangeFile
+	suite.Equal(uint64(1), header.ID)
+	suite.Equal(FormatVer, header.FormatVer)
+	suite.Equal(onec.EncodingUTF8, header.DetectedEncoding)
+	suite.Empty(header.Encoding)
+	suite.Equal([]string{"40702810000000000001"}, header.Account)
+	suite.Equal("01.03.2025", header.StartDateStr)
+	suite.Equal("02.03.2025", header.EndDateStr)
+
+	suite.Require().Len(result.Remainings, 1)
+	balance := result.Remainings[0]
+	suite.Equal(uint64(2), balance.ID)
+	suite.Equal(uint64(1), balance.ExchangeFileID)
+	suite.Equal("40702810000000000001", balance.Account)
+	suite.Equal(money.MustParse("1000"), balance.InitialBalance)
+	suite.Equal(money.MustParse("250"), balance.Income)
+	suite.Equal(money.MustParse("110.50"), balance.WriteOff)
+	suite.Equal(money.MustParse("1139.50"), balance.FinalBalance)
+
+	suite.Require().Len(result.PaymentDocuments, 3)
+
+	payment := result.PaymentDocuments[0]
+	suite.Equal("Платежное поручение", payment.DocumentType)
+	suite.Equal("15", payment.Number)
+	suite.Equal("01.03.2025", payment.DataStr)
+	suite.Equal("01.03.2025", payment.WrittenOffDateStr)
+	suite.Equal(money.MustParse("100.50"), payment.Summ)
+	suite.Equal("40702810000000000001", payment.PayerAccount)
+	suite.Equal("044525225", payment.PayerBIK)
+	suite.Equal("40702810900000000002", payment.ReceiverAccount)
+	suite.Equal("044525593", payment.ReceiverBIK)
+	suite.Equal(`ООО "Ромашка"`, payment.Receiver)
+	suite.Equal("Оплата по договору N 7 от 01.02.2025, без НДС", payment.PaymentPurpose)
+	suite.Equal(balance.ID, payment.AccountBalanceID)
+
+	income := result.PaymentDocuments[1]
+	suite.Equal("B2", income.Number)
+	suite.Equal("40702810000000000001", income.ReceiverAccount)
+	suite.Equal("01.03.2025", income.IncomeDateStr)
+	suite.Nil(income.WrittenOffDate)
+	suite.Equal("Возврат займа по договору N 3", income.PaymentPurpose)
+	suite.Equal(balance.ID, income.AccountBalanceID)
+
+	charge := result.PaymentDocuments[2]
+	suite.Equal("Банковский ордер", charge.DocumentType)
+	suite.Equal(money.MustParse("10"), charge.Summ)
+
+	suite.True(result.Reconcile().IsConsistent())
+}
+
+func (suite *MT940TestSuite) TestScanWindows1251() {
+	data, err := os.ReadFile("fixtures/statement.sta")
+	suite.Require().NoError(err)
+
+	encoded, err := charmap.Windows1251.NewEncoder().Bytes(data)
+	suite.Require().NoError(err)
+
+	result, err := (&Parser{}).Scan(
+		context.Background(),
+		bytes.NewReader(encoded),
+		ids.NewCounter(0),
+	)
+	suite.Require().NoError(err)
+
+	suite.Equal(onec.EncodingWindows1251, result.ExchangeFile.DetectedEncoding)
+	suite.Equal(`ООО "Ромашка"`, result.PaymentDocuments[0].Receiver)
+}
+
+func (suite *MT940TestSuite) TestErrors() {
+	_, err := (&Parser{}).Scan(
+		context.Background(),
+		bytes.NewBufferString("hello"),
+		ids.NewCounter(0),
+	)
+	suite.ErrorIs(err, ErrNoStatement)
+
+	_, err = (&Parser{}).Scan(
+		context.Background(),
+		bytes.NewBufferString(":20:A\n:61:250301X1,00NTRF\n"),
+		ids.NewCounter(0),
+	)
+	suite.ErrorIs(err, ErrField)
+	suite.ErrorContains(err, "line 2")
+}
+
+func (suite *MT940TestSuite) TestParseDetails() {
+	// строки свободного текста склеиваются через пробел
+	suite.Equal(
+		counterparty{purpose: "Оплата по счету N 12 от 03.03.2025 без НДС"},
+		parseDetails("Оплата по счету N 12\nот 03.03.2025\nбез НДС"),
+	)
+
+	// перенос перед подполем не добавляет пробел в текст предыдущего подполя
+	suite.Equal(
+		counterparty{
+			purpose: "Оплата по договору N 7",
+			bik:     "044525593",
+			account: "40702810900000000002",
+			name:    `ООО "Ромашка и партнеры"`,
+		},
+		parseDetails("?20Оплата по договору N 7\n?30044525593\n?3140702810900000000002\n"+
+			`?32ООО "Ромашка`+"\n"+`и партнеры"`),
+	)
+}
+
+func TestMT940TestSuite(t *testing.T) {
+	suite.Run(t, new(MT940TestSuite))
+}
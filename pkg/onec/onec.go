@@ -1,12 +1,18 @@
 package onec
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var ErrIDAllocation = errors.New("unable to allocate ids")
+
 // Helper functions.
 func ParseDateTime(date string) *time.Time {
 	if date == "" {
@@ -71,8 +77,97 @@ type Result struct {
 	PaymentDocuments []PaymentDocument
 }
 
+// IDAllocator hands out ids of parsed entities: the header, balances and payment documents.
+// Implementations may reserve ids in blocks, see package ids.
+type IDAllocator interface {
+	// Allocate returns n unique ids.
+	Allocate(ctx context.Context, n int) ([]uint64, error)
+}
+
+// NextID allocates a single id.
+func NextID(ctx context.Context, ids IDAllocator) (uint64, error) {
+	res, err := ids.Allocate(ctx, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(res) != 1 {
+		return 0, fmt.Errorf("%w: got %d ids instead of 1", ErrIDAllocation, len(res))
+	}
+
+	return res[0], nil
+}
+
+// AssignIDs sets ids of all entities of the result not linked yet with a single allocation,
+// balances get the header id as ExchangeFileID. Documents are linked to balances afterwards.
+func (r *Result) AssignIDs(ctx context.Context, ids IDAllocator) error {
+	n := 1 + len(r.Remainings) + len(r.PaymentDocuments)
+
+	res, err := ids.Allocate(ctx, n)
+	if err != nil {
+		return err
+	}
+
+	if len(res) != n {
+		return fmt.Errorf("%w: got %d ids instead of %d", ErrIDAllocation, len(res), n)
+	}
+
+	r.ExchangeFile.ID, res = res[0], res[1:]
+
+	for i := range r.Remainings {
+		r.Remainings[i].ID, r.Remainings[i].ExchangeFileID = res[i], r.ExchangeFile.ID
+	}
+
+	res = res[len(r.Remainings):]
+
+	for i := range r.PaymentDocuments {
+		r.PaymentDocuments[i].ID = res[i]
+	}
+
+	return nil
+}
+
 type Parser interface {
-	Scan(io.Reader, func() (uint64, error)) (*Result, error)
+	Scan(context.Context, io.Reader, IDAllocator) (*Result, error)
+}
+
+// Item is a single entity read from an exchange file, exactly one of the fields is set.
+type Item struct {
+	ExchangeFile *ExchangeFile
+	Balance      *AccountBalance
+	Document     *PaymentDocument
+}
+
+// StreamParser returns entities as they are read instead of collecting the whole file in memory.
+// The header always comes first, documents are linked to the balances read before them.
+type StreamParser interface {
+	Stream(context.Context, io.Reader, IDAllocator) iter.Seq2[Item, error]
+}
+
+// Items returns the header, the balances and the documents of the result in the order
+// StreamParser yields them.
+func (r *Result) Items() iter.Seq[Item] {
+	return func(yield func(Item) bool) {
+		if !yield(Item{ExchangeFile: &r.ExchangeFile}) {
+			return
+		}
+
+		for i := range r.Remainings {
+			if !yield(Item{Balance: &r.Remainings[i]}) {
+				return
+			}
+		}
+
+		for i := range r.PaymentDocuments {
+			if !yield(Item{Document: &r.PaymentDocuments[i]}) {
+				return
+			}
+		}
+	}
+}
+
+type Writer interface {
+	Write(io.Writer, *Result) error
 }
 
 // ProcessBalanceAndDocs optimized version using maps for O(n+m) complexity.
@@ -81,49 +176,60 @@ func (r *Result) ProcessBalanceAndDocs() *Result {
 		return r
 	}
 
-	// Group remainings by account for fast lookup
-	remainingsByAccount := make(map[string][]AccountBalance, len(r.Remainings))
-	for _, remaining := range r.Remainings {
-		remainingsByAccount[remaining.Account] = append(
-			remainingsByAccount[remaining.Account],
-			remaining,
-		)
-	}
+	balances := NewBalanceIndex(r.Remainings)
 
 	// Process each payment document once
 	for j := range r.PaymentDocuments {
-		doc := &r.PaymentDocuments[j]
+		balances.Link(&r.PaymentDocuments[j])
+	}
+
+	return r
+}
 
-		// Check payer account first
-		if doc.WrittenOffDate != nil {
-			if remainings, exists := remainingsByAccount[doc.PayerAccount]; exists {
-				for _, remaining := range remainings {
-					if isDateInRange(doc.WrittenOffDate, remaining.StartDate, remaining.EndDate) {
-						r.PaymentDocuments[j].AccountBalanceID = remaining.ID
+// BalanceIndex groups account balances by account for fast lookup of the balance a document belongs to.
+type BalanceIndex map[string][]AccountBalance
 
-						goto nextDoc // Found match, skip receiver check
-					}
-				}
-			}
-		}
+func NewBalanceIndex(balances []AccountBalance) BalanceIndex {
+	idx := make(BalanceIndex, len(balances))
+	for _, balance := range balances {
+		idx.Add(balance)
+	}
 
-		// Check receiver account only if payer didn't match
-		if doc.IncomeDate != nil {
-			if remainings, exists := remainingsByAccount[doc.ReceiverAccount]; exists {
-				for _, remaining := range remainings {
-					if isDateInRange(doc.IncomeDate, remaining.StartDate, remaining.EndDate) {
-						r.PaymentDocuments[j].AccountBalanceID = remaining.ID
+	return idx
+}
 
-						break
-					}
-				}
-			}
-		}
+func (idx BalanceIndex) Add(balance AccountBalance) {
+	idx[balance.Account] = append(idx[balance.Account], balance)
+}
 
-	nextDoc:
+// Link sets AccountBalanceID of the document to the balance whose period contains
+// the write-off date of the payer account or, failing that, the income date of the receiver account.
+func (idx BalanceIndex) Link(doc *PaymentDocument) {
+	// Check payer account first
+	if id, ok := idx.find(doc.PayerAccount, doc.WrittenOffDate); ok {
+		doc.AccountBalanceID = id
+
+		return
 	}
 
-	return r
+	// Check receiver account only if payer didn't match
+	if id, ok := idx.find(doc.ReceiverAccount, doc.IncomeDate); ok {
+		doc.AccountBalanceID = id
+	}
+}
+
+func (idx BalanceIndex) find(account string, date *time.Time) (uint64, bool) {
+	if date == nil {
+		return 0, false
+	}
+
+	for _, remaining := range idx[account] {
+		if isDateInRange(date, remaining.StartDate, remaining.EndDate) {
+			return remaining.ID, true
+		}
+	}
+
+	return 0, false
 }
 
 // isDateInRange checks if date is within the range [start, end] inclusive.
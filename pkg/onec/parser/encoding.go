@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+// sniffSize сколько байт начала файла просматривается для определения кодировки,
+// заголовок с ключом Кодировка гарантированно помещается.
+const sniffSize = 64 * 1024
+
+var (
+	utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+	// encodingKey ключ заголовка "Кодировка=" в однобайтовых кодировках,
+	// найденная форма ключа однозначно определяет кодировку файла.
+	encodingKey = []struct {
+		name string
+		key  []byte
+	}{
+		{onec.EncodingWindows1251, mustEncode(charmap.Windows1251, "Кодировка=")},
+		{onec.EncodingCP866, mustEncode(charmap.CodePage866, "Кодировка=")},
+	}
+)
+
+func mustEncode(cm *charmap.Charmap, s string) []byte {
+	res, err := cm.NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// convertFileEncoding decodes the file into UTF-8, the encoding is taken from the parser settings
+// or detected from the first bytes of the file.
+func (p *ExchangeFile) convertFileEncoding(file io.Reader) (io.Reader, string) {
+	return Decode(file, p.Encoding)
+}
+
+// Decode returns the file decoded into UTF-8 and the encoding used: name when it is set,
+// otherwise the one detected by DetectEncoding. Other statement formats of Russian banks
+// (MT940, plain text registers) come in the same encodings as exchange files.
+func Decode(file io.Reader, name string) (io.Reader, string) {
+	buffered := bufio.NewReaderSize(file, sniffSize)
+
+	if name == "" {
+		// короткий файл не ошибка, ошибка чтения вернется при сканировании
+		head, _ := buffered.Peek(sniffSize) //nolint:errcheck
+		name = DetectEncoding(head)
+	}
+
+	return transform.NewReader(buffered, decoder(name)), name
+}
+
+func decoder(name string) transform.Transformer {
+	var enc encoding.Encoding
+
+	switch name {
+	case onec.EncodingCP866:
+		enc = charmap.CodePage866
+	case onec.EncodingUTF8:
+		enc = unicode.UTF8BOM // снимает BOM, если он есть
+	default:
+		enc = charmap.Windows1251
+	}
+
+	return enc.NewDecoder()
+}
+
+// DetectEncoding picks Windows-1251, CP866 or UTF-8 for the beginning of an exchange file.
+// The bytes win over the declaration (Кодировка=Windows|DOS), banks sending UTF-8 often keep
+// Кодировка=Windows: a UTF-8 BOM or valid UTF-8 text means UTF-8, otherwise the declaration
+// is looked up in both single-byte forms and the form found is the encoding of the file.
+// Files without the declaration are told apart by the distribution of Cyrillic letters.
+func DetectEncoding(head []byte) string {
+	if bytes.HasPrefix(head, utf8BOM) {
+		return onec.EncodingUTF8
+	}
+
+	if hasNonASCII(head) && utf8.Valid(trimIncompleteRune(head)) {
+		return onec.EncodingUTF8
+	}
+
+	for _, candidate := range encodingKey {
+		if bytes.Contains(head, candidate.key) {
+			return candidate.name
+		}
+	}
+
+	return sniffSingleByte(head)
+}
+
+// sniffSingleByte distinguishes CP866 from Windows-1251 by letter ranges:
+// in CP866 most Cyrillic letters are 0x80-0xAF, in Windows-1251 they are 0xC0-0xFF.
+func sniffSingleByte(head []byte) string {
+	var cp866, cp1251 int
+
+	for _, b := range head {
+		switch {
+		case b >= 0x80 && b <= 0xAF:
+			cp866++
+		case b >= 0xC0 && b <= 0xDF, b >= 0xF0:
+			cp1251++
+		}
+	}
+
+	if cp866 > cp1251 {
+		return onec.EncodingCP866
+	}
+
+	return onec.EncodingWindows1251
+}
+
+func hasNonASCII(data []byte) bool {
+	for _, b := range data {
+		if b >= utf8.RuneSelf {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimIncompleteRune drops a rune cut off by the end of the sniffed block.
+func trimIncompleteRune(data []byte) []byte {
+	for i := 0; i < utf8.UTFMax && len(data) > i; i++ {
+		r, size := utf8.DecodeLastRune(data[:len(data)-i])
+		if r != utf8.RuneError || size != 1 {
+			return data[:len(data)-i]
+		}
+	}
+
+	return data
+}
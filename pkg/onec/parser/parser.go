@@ -2,102 +2,171 @@ package parser
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
-	"golang.org/x/text/encoding/charmap"
-	"golang.org/x/text/transform"
 
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
 )
 
+// ExchangeFile parses files in the 1CClientBankExchange format,
+// Encoding forces one of onec.Encoding* instead of detecting it from the file.
 type ExchangeFile struct {
-	exchangeFile     map[string]string
-	accountBalance   []map[string]string
-	paymentDocuments []map[string]string
+	Encoding string
 }
 
-var _ onec.Parser = (*ExchangeFile)(nil)
+var (
+	_ onec.Parser       = (*ExchangeFile)(nil)
+	_ onec.StreamParser = (*ExchangeFile)(nil)
+)
 
-func (p *ExchangeFile) Scan(file io.Reader, next func() (uint64, error)) (*onec.Result, error) {
-	if err := p.read(p.convertFileEncoding(file)); err != nil {
-		return nil, err
-	}
+// errStopped прерывает чтение файла, когда потребитель итератора вышел из цикла.
+var errStopped = errors.New("stream stopped")
 
-	exFile, err := p.convertFile()
-	if err != nil {
-		return nil, err
+func (p *ExchangeFile) Scan(
+	ctx context.Context,
+	file io.Reader,
+	ids onec.IDAllocator,
+) (*onec.Result, error) {
+	result := &onec.Result{}
+
+	for item, err := range p.Stream(ctx, file, ids) {
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case item.ExchangeFile != nil:
+			result.ExchangeFile = *item.ExchangeFile
+		case item.Balance != nil:
+			result.Remainings = append(result.Remainings, *item.Balance)
+		case item.Document != nil:
+			result.PaymentDocuments = append(result.PaymentDocuments, *item.Document)
+		}
 	}
 
-	exFile.ID, err = next()
-	if err != nil {
-		return nil, err
+	if result.Remainings == nil {
+		result.Remainings = []onec.AccountBalance{}
 	}
 
-	rem, err := p.convertAccountBalance()
-	if err != nil {
-		return nil, err
+	if result.PaymentDocuments == nil {
+		result.PaymentDocuments = []onec.PaymentDocument{}
 	}
 
-	for i := range rem {
-		rem[i].ExchangeFileID = exFile.ID
+	// документы, встретившиеся в файле раньше остатков, при потоковом чтении не связываются
+	return result.ProcessBalanceAndDocs(), nil
+}
 
-		rem[i].ID, err = next()
-		if err != nil {
-			return nil, err
+// Stream reads the file section by section and yields the header, balances and payment documents
+// as soon as each of them is complete. Only the balances are kept in memory to link documents to them.
+// Every entity takes one id from ids, wrap a remote allocator into ids.Block to reserve them in blocks.
+func (p *ExchangeFile) Stream(
+	ctx context.Context,
+	file io.Reader,
+	ids onec.IDAllocator,
+) iter.Seq2[onec.Item, error] {
+	return func(yield func(onec.Item, error) bool) {
+		decoded, encoding := p.convertFileEncoding(file)
+
+		s := &streamer{
+			ctx:      ctx,
+			ids:      ids,
+			yield:    yield,
+			encoding: encoding,
+			balances: onec.BalanceIndex{},
 		}
-	}
 
-	pd, err := p.convertPaymentDocuments()
-	if err != nil {
-		return nil, err
-	}
+		err := p.read(decoded, s.handle)
+		if err == nil {
+			err = s.finish()
+		}
 
-	result := &onec.Result{
-		ExchangeFile:     exFile,
-		Remainings:       rem,
-		PaymentDocuments: pd,
+		if err != nil && !errors.Is(err, errStopped) {
+			yield(onec.Item{}, err)
+		}
 	}
+}
 
-	return result.ProcessBalanceAndDocs(), nil
+type sectionKind int
+
+const (
+	sectionNone sectionKind = iota
+	sectionHeader
+	sectionBalance
+	sectionDocument
+)
+
+type section struct {
+	kind   sectionKind
+	line   int
+	fields map[string]string
+	lines  map[string]int
 }
 
-func (*ExchangeFile) convertFileEncoding(file io.Reader) io.Reader {
-	return transform.NewReader(file, charmap.Windows1251.NewDecoder())
+func newSection(kind sectionKind, line int) section {
+	return section{
+		kind:   kind,
+		line:   line,
+		fields: make(map[string]string),
+		lines:  make(map[string]int),
+	}
 }
 
-func (p *ExchangeFile) read(file io.Reader) error {
+// read passes every complete section to handle, a section is complete on its end marker,
+// on the start of the next section or at the end of the file.
+func (p *ExchangeFile) read(file io.Reader, handle func(section) error) error {
 	scanner := bufio.NewScanner(file)
 
-	const maxCapacity = 1024 * 1024 * 40 // 40MB эмпирический размер на файл
+	const maxCapacity = 1024 * 1024 * 40 // 40MB эмпирический максимальный размер строки
 
-	buf := make([]byte, 0, maxCapacity)
-	scanner.Buffer(buf, maxCapacity)
+	scanner.Buffer(nil, maxCapacity)
 
 	var (
-		currentSection map[string]string
-		inSectionType  int
+		current = section{kind: sectionNone}
+		lineNo  int
 	)
 
+	flush := func() error {
+		if current.kind == sectionNone {
+			return nil
+		}
+
+		sec := current
+		current = section{kind: sectionNone}
+
+		return handle(sec)
+	}
+
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNo++
 
 		switch {
 		case strings.HasPrefix(line, "1CClientBankExchange"):
-			p.exchangeFile = make(map[string]string)
-			currentSection = p.exchangeFile
-			inSectionType = 1
+			if err := flush(); err != nil {
+				return err
+			}
+
+			current = newSection(sectionHeader, lineNo)
 
 		case strings.HasPrefix(line, "СекцияРасчСчет"):
-			currentSection = make(map[string]string)
-			p.accountBalance = append(p.accountBalance, currentSection)
-			inSectionType = 2
+			if err := flush(); err != nil {
+				return err
+			}
+
+			current = newSection(sectionBalance, lineNo)
 
 		case strings.HasPrefix(line, "СекцияДокумент"):
+			if err := flush(); err != nil {
+				return err
+			}
+
 			parts := strings.SplitN(line, "=", 2)
 			docType := ""
 
@@ -105,30 +174,32 @@ func (p *ExchangeFile) read(file io.Reader) error {
 				docType = parts[1]
 			}
 
-			currentSection = make(map[string]string)
-			currentSection["СекцияДокумент"] = docType
-			p.paymentDocuments = append(p.paymentDocuments, currentSection)
-			inSectionType = 3
+			current = newSection(sectionDocument, lineNo)
+			current.fields["СекцияДокумент"] = docType
+			current.lines["СекцияДокумент"] = lineNo
 
 		case strings.HasPrefix(line, "КонецРасчСчет"), strings.HasPrefix(line, "КонецДокумента"):
-			inSectionType = 0
+			if err := flush(); err != nil {
+				return err
+			}
 
 		case strings.HasPrefix(line, "КонецФайла"):
-			return nil
+			return flush()
 
 		default:
-			if inSectionType != 0 && currentSection != nil {
+			if current.kind != sectionNone {
 				keyVal := strings.SplitN(strings.TrimSpace(line), "=", 2)
-				if val, exist := currentSection[keyVal[0]]; len(keyVal) == 2 &&
-					inSectionType == 1 &&
+				if val, exist := current.fields[keyVal[0]]; len(keyVal) == 2 &&
+					current.kind == sectionHeader &&
 					exist {
-					currentSection[keyVal[0]] = val + "," + keyVal[1]
+					current.fields[keyVal[0]] = val + "," + keyVal[1]
 
 					continue
 				}
 
 				if len(keyVal) == 2 {
-					currentSection[keyVal[0]] = keyVal[1]
+					current.fields[keyVal[0]] = keyVal[1]
+					current.lines[keyVal[0]] = lineNo
 				}
 			}
 		}
@@ -142,96 +213,184 @@ func (p *ExchangeFile) read(file io.Reader) error {
 		return fmt.Errorf("error reading data: %w", err)
 	}
 
+	return flush()
+}
+
+// streamer converts sections into onec entities and passes them to the iterator consumer.
+type streamer struct {
+	ctx      context.Context //nolint:containedctx // живет только на время Stream
+	ids      onec.IDAllocator
+	yield    func(onec.Item, error) bool
+	encoding string
+	header   *onec.ExchangeFile
+	balances onec.BalanceIndex
+}
+
+func (s *streamer) handle(sec section) error {
+	// отмена прерывает чтение большого файла, даже если генератор ID не смотрит на контекст
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	if sec.kind == sectionHeader {
+		return s.emitHeader(sec.fields)
+	}
+
+	if s.header == nil {
+		if err := s.emitHeader(nil); err != nil {
+			return err
+		}
+	}
+
+	switch sec.kind { //nolint:exhaustive
+	case sectionBalance:
+		return s.emitBalance(sec.fields)
+	case sectionDocument:
+		return s.emitDocument(sec.fields)
+	}
+
 	return nil
 }
 
-func (p *ExchangeFile) convertFile() (onec.ExchangeFile, error) {
-	var exFile onec.ExchangeFile
+// finish emits an empty header for a file without sections, like the whole-file parser did.
+func (s *streamer) finish() error {
+	if s.header != nil {
+		return nil
+	}
 
-	config := &mapstructure.DecoderConfig{
-		WeaklyTypedInput: true,
-		Result:           &exFile,
+	return s.emitHeader(nil)
+}
+
+func (s *streamer) emit(item onec.Item) error {
+	if !s.yield(item, nil) {
+		return errStopped
 	}
 
-	decoder, err := mapstructure.NewDecoder(config)
+	return nil
+}
+
+func (s *streamer) emitHeader(fields map[string]string) error {
+	exFile, err := convertFile(fields)
 	if err != nil {
-		return exFile, fmt.Errorf("error creating new mapstructure decoder: %w", err)
+		return err
 	}
 
-	if err := decoder.Decode(p.exchangeFile); err != nil {
-		return exFile, fmt.Errorf("error while decoding ExchangeFile: %w", err)
+	exFile.ID, err = onec.NextID(s.ctx, s.ids)
+	if err != nil {
+		return err
 	}
 
-	exFile.StartDate = onec.ParseDate(exFile.StartDateStr)
-	exFile.EndDate = onec.ParseDateTime(exFile.EndDateStr + " 23:59:59")
-	exFile.CreatedDate = onec.ParseDateTime(exFile.CreatedDateStr + " " + exFile.CreatedTimeStr)
+	exFile.DetectedEncoding = s.encoding
+	s.header = &exFile
 
-	return exFile, nil
+	return s.emit(onec.Item{ExchangeFile: &exFile})
 }
 
-func (p *ExchangeFile) convertAccountBalance() ([]onec.AccountBalance, error) {
-	remainings := make([]onec.AccountBalance, 0, len(p.accountBalance))
+func (s *streamer) emitBalance(fields map[string]string) error {
+	remaining, err := convertAccountBalance(fields)
+	if err != nil {
+		return err
+	}
 
-	for i := range p.accountBalance {
-		var remaining onec.AccountBalance
+	remaining.ExchangeFileID = s.header.ID
 
-		config := &mapstructure.DecoderConfig{
-			WeaklyTypedInput: true,
-			Result:           &remaining,
-		}
+	remaining.ID, err = onec.NextID(s.ctx, s.ids)
+	if err != nil {
+		return err
+	}
 
-		decoder, err := mapstructure.NewDecoder(config)
-		if err != nil {
-			return nil, fmt.Errorf("error creating new mapstructure decode: %w", err)
-		}
+	s.balances.Add(remaining)
 
-		if err := decoder.Decode(p.accountBalance[i]); err != nil {
-			return nil, fmt.Errorf("error while decode remaining: %w", err)
-		}
+	return s.emit(onec.Item{Balance: &remaining})
+}
 
-		remaining.StartDate = onec.ParseDate(remaining.StartDateStr)
-		remaining.EndDate = onec.ParseDateTime(remaining.EndDateStr + " 23:59:59")
+func (s *streamer) emitDocument(fields map[string]string) error {
+	pd, ok, err := convertPaymentDocument(fields)
+	if err != nil || !ok {
+		return err
+	}
 
-		remainings = append(remainings, remaining)
+	pd.ID, err = onec.NextID(s.ctx, s.ids)
+	if err != nil {
+		return err
 	}
 
-	return remainings, nil
+	s.balances.Link(&pd)
+
+	return s.emit(onec.Item{Document: &pd})
 }
 
-func (p *ExchangeFile) convertPaymentDocuments() ([]onec.PaymentDocument, error) {
-	paymentDocuments := make([]onec.PaymentDocument, 0, len(p.paymentDocuments))
-	for i := range p.paymentDocuments {
-		var pd onec.PaymentDocument
+func decode(fields map[string]string, result any, hook mapstructure.DecodeHookFunc) error {
+	// суммы (money.Money) разбираются через encoding.TextUnmarshaler
+	hooks := mapstructure.ComposeDecodeHookFunc(mapstructure.TextUnmarshallerHookFunc())
+	if hook != nil {
+		hooks = mapstructure.ComposeDecodeHookFunc(hook, mapstructure.TextUnmarshallerHookFunc())
+	}
 
-		config := &mapstructure.DecoderConfig{
-			WeaklyTypedInput: true,
-			Result:           &pd,
-		}
+	config := &mapstructure.DecoderConfig{
+		DecodeHook:       hooks,
+		WeaklyTypedInput: true,
+		Result:           result,
+	}
 
-		decoder, err := mapstructure.NewDecoder(config)
-		if err != nil {
-			return nil, fmt.Errorf("error creating new mapstructure decode: %w", err)
-		}
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return fmt.Errorf("error creating new mapstructure decoder: %w", err)
+	}
 
-		if err := decoder.Decode(p.paymentDocuments[i]); err != nil {
-			return nil, fmt.Errorf("error while decode payment document: %w", err)
-		}
+	return decoder.Decode(fields)
+}
 
-		if pd.Summ > 0 {
-			if len(pd.RectDateStr) == 5 {
-				pd.RectDateStr += ":00"
-			}
+func convertFile(fields map[string]string) (onec.ExchangeFile, error) {
+	var exFile onec.ExchangeFile
 
-			pd.Data = onec.ParseDate(pd.DataStr)
-			pd.WrittenOffDate = onec.ParseDate(pd.WrittenOffDateStr)
-			pd.IncomeDate = onec.ParseDate(pd.IncomeDateStr)
-			pd.RectDateTime = onec.ParseDateTime(pd.RectDateStr + " " + pd.RectTimeStr)
-			pd.IndicatorDate = onec.ParseDate(pd.IndicatorDateStr)
-			pd.DocumentSendingDate = onec.ParseDateTime(utils.FromPtr(pd.DocumentSendingDateStr))
+	// РасчСчет может повторяться в заголовке, значения склеиваются через запятую в read
+	if err := decode(fields, &exFile, mapstructure.StringToSliceHookFunc(",")); err != nil {
+		return exFile, fmt.Errorf("error while decoding ExchangeFile: %w", err)
+	}
 
-			paymentDocuments = append(paymentDocuments, pd)
-		}
+	exFile.StartDate = onec.ParseDate(exFile.StartDateStr)
+	exFile.EndDate = onec.ParseDateTime(exFile.EndDateStr + " 23:59:59")
+	exFile.CreatedDate = onec.ParseDateTime(exFile.CreatedDateStr + " " + exFile.CreatedTimeStr)
+
+	return exFile, nil
+}
+
+func convertAccountBalance(fields map[string]string) (onec.AccountBalance, error) {
+	var remaining onec.AccountBalance
+
+	if err := decode(fields, &remaining, nil); err != nil {
+		return remaining, fmt.Errorf("error while decode remaining: %w", err)
+	}
+
+	remaining.StartDate = onec.ParseDate(remaining.StartDateStr)
+	remaining.EndDate = onec.ParseDateTime(remaining.EndDateStr + " 23:59:59")
+
+	return remaining, nil
+}
+
+// convertPaymentDocument returns ok == false for documents without a positive sum, they are skipped.
+func convertPaymentDocument(fields map[string]string) (onec.PaymentDocument, bool, error) {
+	var pd onec.PaymentDocument
+
+	if err := decode(fields, &pd, nil); err != nil {
+		return pd, false, fmt.Errorf("error while decode payment document: %w", err)
+	}
+
+	if pd.Summ <= 0 {
+		return pd, false, nil
 	}
 
-	return paymentDocuments, nil
+	if len(pd.RectDateStr) == 5 {
+		pd.RectDateStr += ":00"
+	}
+
+	pd.Data = onec.ParseDate(pd.DataStr)
+	pd.WrittenOffDate = onec.ParseDate(pd.WrittenOffDateStr)
+	pd.IncomeDate = onec.ParseDate(pd.IncomeDateStr)
+	pd.RectDateTime = onec.ParseDateTime(pd.RectDateStr + " " + pd.RectTimeStr)
+	pd.IndicatorDate = onec.ParseDate(pd.IndicatorDateStr)
+	pd.DocumentSendingDate = onec.ParseDateTime(utils.FromPtr(pd.DocumentSendingDateStr))
+
+	return pd, true, nil
 }
@@ -1,12 +1,22 @@
 package parser
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/encoding/charmap"
 
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/validation"
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
 )
 
@@ -33,7 +43,7 @@ func (suite *ParserTestSuite) TestScan() {
 	suite.NoError(err)
 
 	// Call the Scan function
-	result, err := p.Scan(r, sonyflake.NextID)
+	result, err := p.Scan(context.Background(), r, ids.NewSonyflake(sonyflake))
 
 	// Assert that there is no error
 	suite.NoError(err)
@@ -59,10 +69,10 @@ func (suite *ParserTestSuite) TestScan() {
 	suite.Equal("01.01.2019", rab.StartDateStr)
 	suite.Equal("31.12.2021", rab.EndDateStr)
 	suite.Equal("12345678901234567890", rab.Account)
-	suite.Equal(10.0, rab.InitialBalance)
-	suite.Equal(2.0, rab.Income)
-	suite.Equal(1.0, rab.WriteOff)
-	suite.Equal(11.0, rab.FinalBalance)
+	suite.Equal(money.MustParse("10"), rab.InitialBalance)
+	suite.Equal(money.MustParse("2"), rab.Income)
+	suite.Equal(money.MustParse("1"), rab.WriteOff)
+	suite.Equal(money.MustParse("11"), rab.FinalBalance)
 
 	// 3. Payment documents
 	suite.Len(result.PaymentDocuments, 2)
@@ -72,7 +82,7 @@ func (suite *ParserTestSuite) TestScan() {
 	suite.Equal("Банковский ордер", d1.DocumentType)
 	suite.Equal("1", d1.Number)
 	suite.Equal("01.02.2021", d1.DataStr)
-	suite.Equal(2.0, d1.Summ)
+	suite.Equal(money.MustParse("2"), d1.Summ)
 	suite.Equal("12345678901234567890", d1.PayerAccount)
 	suite.Equal(
 		"ОБЩЕСТВО С ОГРАНИЧЕННОЙ ОТВЕТСТВЕННОСТЬЮ \"РОГА И КОПЫТА\" (ФИРМА РОГА И КОПЫТА LTD.)",
@@ -90,7 +100,7 @@ func (suite *ParserTestSuite) TestScan() {
 	suite.Equal("Платежное поручение", d2.DocumentType)
 	suite.Equal("2", d2.Number)
 	suite.Equal("01.03.2021", d2.DataStr)
-	suite.Equal(1.0, d2.Summ)
+	suite.Equal(money.MustParse("1"), d2.Summ)
 	suite.Equal("12345678901234567890", d2.PayerAccount)
 	suite.Equal(
 		"ОБЩЕСТВО С ОГРАНИЧЕННОЙ ОТВЕТСТВЕННОСТЬЮ \"ФИРМА РОГА И КОПЫТА\" (ФИРМА ФИРМА РОГА И КОПЫТА LTD.)",
@@ -105,6 +115,247 @@ func (suite *ParserTestSuite) TestScan() {
 	outputFile.Close()
 }
 
+func (suite *ParserTestSuite) TestStream() {
+	file, err := os.Open("fixtures/0.txt")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	var items []onec.Item
+
+	for item, err := range (&ExchangeFile{}).Stream(context.Background(), file, ids.NewCounter(0)) {
+		suite.Require().NoError(err)
+
+		items = append(items, item)
+	}
+
+	suite.Require().Len(items, 4)
+	suite.Require().NotNil(items[0].ExchangeFile)
+	suite.Equal(uint64(1), items[0].ExchangeFile.ID)
+	suite.Require().NotNil(items[1].Balance)
+	suite.Equal(uint64(1), items[1].Balance.ExchangeFileID)
+	suite.Require().NotNil(items[2].Document)
+	suite.Equal("1", items[2].Document.Number)
+	suite.Equal(uint64(3), items[2].Document.ID)
+	suite.Equal(items[1].Balance.ID, items[2].Document.AccountBalanceID)
+	suite.Require().NotNil(items[3].Document)
+	suite.Equal("2", items[3].Document.Number)
+	suite.Equal(uint64(4), items[3].Document.ID)
+}
+
+func (suite *ParserTestSuite) TestStreamBreak() {
+	file, err := os.Open("fixtures/0.txt")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	sonyflake, err := utils.NewSonyflake(utils.SonyflakeConfig{MachineID: 1})
+	suite.Require().NoError(err)
+
+	count := 0
+
+	for _, err := range (&ExchangeFile{}).Stream(context.Background(), file, ids.NewSonyflake(sonyflake)) {
+		suite.Require().NoError(err)
+
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	suite.Equal(2, count)
+}
+
+func (suite *ParserTestSuite) TestStreamError() {
+	noIDs := ids.Func(func(context.Context) (uint64, error) {
+		return 0, errors.New("no ids left")
+	})
+
+	var errs []error
+
+	for _, err := range (&ExchangeFile{}).Stream(
+		context.Background(),
+		strings.NewReader("1CClientBankExchange\r\n"),
+		noIDs,
+	) {
+		errs = append(errs, err)
+	}
+
+	suite.Require().Len(errs, 1)
+	suite.EqualError(errs[0], "no ids left")
+}
+
+func (suite *ParserTestSuite) TestStreamCanceled() {
+	file, err := os.Open("fixtures/0.txt")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		items int
+		errs  []error
+	)
+
+	for _, err := range (&ExchangeFile{}).Stream(ctx, file, ids.NewCounter(0)) {
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		// счетчик ID не смотрит на контекст, чтение останавливает сам парсер
+		items++
+		cancel()
+	}
+
+	suite.Equal(1, items)
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], context.Canceled)
+}
+
+func (suite *ParserTestSuite) TestValidate() {
+	content := strings.Join([]string{
+		"1CClientBankExchange",
+		"ВерсияФормата=1.03",
+		"Кодировка=Windows",
+		"ДатаНачала=01.01.2024",
+		"ДатаКонца=31.01.2024",
+		"РасчСчет=40702810000000001234",
+		"СекцияРасчСчет",
+		"ДатаНачала=01.01.2024",
+		"РасчСчет=40702810000000001234",
+		"НачальныйОстаток=10.00",
+		"ВсегоПоступило=5.00",
+		"ВсегоСписано=1.00",
+		"КонечныйОстаток=15.00",
+		"КонецРасчСчет",
+		"СекцияДокумент=Платежное поручение",
+		"Номер=1",
+		"Дата=32.01.2024",
+		"Сумма=0",
+		"ПлательщикСчет=40702810000000001234",
+		"ПолучательСчет=40702810000000005678",
+		"КонецДокумента",
+		"СекцияДокумент=Платежное поручение",
+		"Номер=2",
+		"Дата=15.02.2024",
+		"Сумма=3.00",
+		"ПлательщикСчет=40702810000000001234",
+		"ПлательщикИНН=7706095015",
+		"ПлательщикБИК=044525593",
+		"ПлательщикКорсчет=30101810200000000593",
+		"КонецДокумента",
+		"КонецФайла",
+	}, "\r\n")
+
+	encoded, err := charmap.Windows1251.NewEncoder().String(content)
+	suite.Require().NoError(err)
+
+	report, err := (&ExchangeFile{}).Validate(strings.NewReader(encoded))
+	suite.Require().NoError(err)
+
+	type finding struct {
+		line     int
+		severity validation.Severity
+		field    string
+	}
+
+	var got []finding
+	for _, issue := range report.Issues {
+		got = append(got, finding{issue.Line, issue.Severity, issue.Field})
+	}
+
+	suite.ElementsMatch([]finding{
+		{13, validation.SeverityError, "КонечныйОстаток"},
+		{17, validation.SeverityError, "Дата"},
+		{18, validation.SeverityWarning, "Сумма"},
+		{22, validation.SeverityError, "ПолучательСчет"},
+		{24, validation.SeverityWarning, "Дата"},
+		{27, validation.SeverityWarning, "ПлательщикИНН"},
+	}, got)
+	suite.True(report.HasErrors())
+}
+
+func (suite *ParserTestSuite) TestDetectEncoding() {
+	const header = "1CClientBankExchange\r\nВерсияФормата=1.03\r\nКодировка=%s\r\nОтправитель=Бухгалтерия\r\n"
+
+	encode := func(cm *charmap.Charmap, s string) []byte {
+		res, err := cm.NewEncoder().Bytes([]byte(s))
+		suite.Require().NoError(err)
+
+		return res
+	}
+
+	cases := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{
+			"windows",
+			encode(charmap.Windows1251, fmt.Sprintf(header, "Windows")),
+			onec.EncodingWindows1251,
+		},
+		{"dos", encode(charmap.CodePage866, fmt.Sprintf(header, "DOS")), onec.EncodingCP866},
+		{
+			"dos declared as windows",
+			encode(charmap.CodePage866, fmt.Sprintf(header, "Windows")),
+			onec.EncodingCP866,
+		},
+		{"utf-8", []byte(fmt.Sprintf(header, "Windows")), onec.EncodingUTF8},
+		{
+			"utf-8 bom",
+			append([]byte{0xEF, 0xBB, 0xBF}, fmt.Sprintf(header, "Windows")...),
+			onec.EncodingUTF8,
+		},
+		{
+			"utf-8 cut rune",
+			[]byte(fmt.Sprintf(header, "Windows"))[:len(fmt.Sprintf(header, "Windows"))-1],
+			onec.EncodingUTF8,
+		},
+		{
+			"no declaration dos",
+			encode(charmap.CodePage866, "Платежное поручение"),
+			onec.EncodingCP866,
+		},
+		{
+			"no declaration windows",
+			encode(charmap.Windows1251, "Платежное поручение"),
+			onec.EncodingWindows1251,
+		},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			suite.Equal(c.want, DetectEncoding(c.head))
+		})
+	}
+}
+
+func (suite *ParserTestSuite) TestScanUTF8() {
+	file, err := os.Open("fixtures/0.txt")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	decoded, err := io.ReadAll(charmap.Windows1251.NewDecoder().Reader(file))
+	suite.Require().NoError(err)
+
+	result, err := (&ExchangeFile{}).Scan(
+		context.Background(),
+		bytes.NewReader(append([]byte{0xEF, 0xBB, 0xBF}, decoded...)),
+		ids.NewCounter(0),
+	)
+	suite.Require().NoError(err)
+
+	suite.Equal(onec.EncodingUTF8, result.ExchangeFile.DetectedEncoding)
+	suite.Equal("Альфа-Бизнес Онлайн", result.ExchangeFile.Sender)
+	suite.Len(result.PaymentDocuments, 2)
+}
+
 func TestParserTestSuite(t *testing.T) {
 	suite.Run(t, new(ParserTestSuite))
 }
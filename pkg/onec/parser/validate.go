@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/validation"
+)
+
+// Validate reads the whole file and reports line-numbered issues instead of failing on the first one:
+// missing required keys, unparsable dates and sums, invalid INN/BIK/account control digits,
+// documents outside of the file period, skipped documents and inconsistent balances.
+// The returned error is set only when the file itself can not be read.
+func (p *ExchangeFile) Validate(file io.Reader) (*validation.Report, error) {
+	v := validation.New()
+	headerSeen := false
+
+	decoded, _ := p.convertFileEncoding(file)
+
+	err := p.read(decoded, func(sec section) error {
+		if sec.kind != sectionHeader && !headerSeen {
+			headerSeen = true
+
+			v.Header(validation.Section{Name: validation.SectionHeader}, &onec.ExchangeFile{})
+		}
+
+		switch sec.kind { //nolint:exhaustive
+		case sectionHeader:
+			headerSeen = true
+
+			exFile, err := convertFile(sec.fields)
+			if err != nil {
+				v.DecodeError(sec.toValidation(validation.SectionHeader), err)
+
+				return nil
+			}
+
+			v.Header(sec.toValidation(validation.SectionHeader), &exFile)
+		case sectionBalance:
+			remaining, err := convertAccountBalance(sec.fields)
+			if err != nil {
+				v.DecodeError(sec.toValidation(validation.SectionBalance), err)
+
+				return nil
+			}
+
+			v.Balance(sec.toValidation(validation.SectionBalance), &remaining)
+		case sectionDocument:
+			pd, ok, err := convertPaymentDocument(sec.fields)
+			if err != nil {
+				v.DecodeError(sec.toValidation(validation.SectionDocument), err)
+
+				return nil
+			}
+
+			v.Document(sec.toValidation(validation.SectionDocument), &pd, !ok)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Report(), nil
+}
+
+func (s section) toValidation(name string) validation.Section {
+	return validation.Section{
+		Name:   name,
+		Line:   s.line,
+		Fields: s.fields,
+		Lines:  s.lines,
+	}
+}
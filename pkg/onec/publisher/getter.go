@@ -0,0 +1,95 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/minioclient"
+)
+
+var ErrObjectURL = errors.New("invalid object url")
+
+// ObjectGetter opens the file of ParseRequest.FileUrl, the caller closes the reader.
+type ObjectGetter interface {
+	Get(ctx context.Context, fileURL string) (io.ReadCloser, error)
+}
+
+// GetterFunc adapts a function to ObjectGetter.
+type GetterFunc func(ctx context.Context, fileURL string) (io.ReadCloser, error)
+
+func (f GetterFunc) Get(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	return f(ctx, fileURL)
+}
+
+// MinioGetter reads files from minio, see ObjectPath for the accepted urls.
+type MinioGetter struct {
+	Client minioclient.Getter
+	Bucket string
+}
+
+var _ ObjectGetter = (*MinioGetter)(nil)
+
+func (g *MinioGetter) Get(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	bucket, object, err := ObjectPath(fileURL, g.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := g.Client.GetObject(ctx, bucket, object, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error while getting object %s/%s: %w", bucket, object, err)
+	}
+
+	// GetObject не обращается к серверу, отсутствие объекта видно только после Stat
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+
+		return nil, fmt.Errorf("error while getting object %s/%s: %w", bucket, object, err)
+	}
+
+	return obj, nil
+}
+
+// ObjectPath splits a file url into a bucket and an object name: s3://bucket/object and
+// http(s)://host/bucket/object name the bucket explicitly, a path without a scheme is an object
+// of the default bucket, the default bucket name at its start is dropped.
+func ObjectPath(fileURL, defaultBucket string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(fileURL))
+	if err != nil {
+		return "", "", fmt.Errorf("%w %q: %w", ErrObjectURL, fileURL, err)
+	}
+
+	var bucket, object string
+
+	switch u.Scheme {
+	case "s3":
+		bucket, object = u.Host, strings.TrimPrefix(u.Path, "/")
+	case "http", "https", "":
+		path := strings.TrimPrefix(u.Path, "/")
+
+		b, o, ok := strings.Cut(path, "/")
+
+		switch {
+		case u.Scheme != "":
+			bucket, object = b, o
+		case ok && b == defaultBucket:
+			bucket, object = b, o
+		default:
+			bucket, object = defaultBucket, path
+		}
+	default:
+		return "", "", fmt.Errorf("%w %q: unsupported scheme %s", ErrObjectURL, fileURL, u.Scheme)
+	}
+
+	if bucket == "" || object == "" {
+		return "", "", fmt.Errorf("%w %q: no bucket or object name", ErrObjectURL, fileURL)
+	}
+
+	return bucket, object, nil
+}
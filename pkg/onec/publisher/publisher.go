@@ -0,0 +1,253 @@
+// Package publisher turns a pb.ParseRequest into an ordered stream of pb.ParseResponse messages:
+// it gets the file, parses it and produces a start marker, the header, balances, documents and
+// an end marker with the totals. All messages are keyed by request_id, so with a key hashing
+// balancer (see WithRequestPartitioning) they land in one partition and keep their order.
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/SOTBI-LLC/sotbi.lib/pkg/api/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/kafka"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/kafka/producer"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+const defaultBatchSize = 100
+
+var (
+	ErrGetFile = errors.New("unable to get file")
+	ErrParse   = errors.New("unable to parse file")
+	ErrProduce = errors.New("unable to produce parse responses")
+)
+
+// WithRequestPartitioning makes the producer choose partitions by the message key,
+// the default round robin balancer of kafka-go spreads a request over partitions.
+func WithRequestPartitioning() func(opts *producer.ProducerOptions) {
+	return producer.WithBalancer(&kafkago.Hash{})
+}
+
+// Report is the outcome of a request, it is also sent in the end marker.
+type Report struct {
+	Status pb.ParseStatus
+	// Published is the number of produced header, balance and document messages
+	Published int
+	// Failed is the number of entities that were read but not produced
+	Failed int
+	Errors []error
+}
+
+func (r *Report) Err() error {
+	return errors.Join(r.Errors...)
+}
+
+func (r *Report) fail(err error) {
+	r.Errors = append(r.Errors, err)
+}
+
+type Publisher struct {
+	getter    ObjectGetter
+	parser    onec.Parser
+	producer  kafka.Producer[*pb.ParseResponse]
+	ids       onec.IDAllocator
+	batchSize int
+	now       func() time.Time
+}
+
+type Option func(*Publisher)
+
+// WithBatchSize sets the number of messages produced at once, 100 by default.
+func WithBatchSize(size int) Option {
+	return func(p *Publisher) {
+		if size > 0 {
+			p.batchSize = size
+		}
+	}
+}
+
+// New creates a publisher, ids allocates ids of the parsed entities, see package ids.
+// A parser implementing onec.StreamParser is streamed, other parsers are scanned as a whole.
+func New(
+	getter ObjectGetter,
+	parser onec.Parser,
+	prod kafka.Producer[*pb.ParseResponse],
+	ids onec.IDAllocator,
+	opts ...Option,
+) *Publisher {
+	p := &Publisher{
+		getter:    getter,
+		parser:    parser,
+		producer:  prod,
+		ids:       ids,
+		batchSize: defaultBatchSize,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Publish handles a request. Failures of getting, parsing or producing a batch do not stop
+// the stream: they are collected into the report and the end marker, the returned error is
+// Report.Err. Only a failure to produce the start marker leaves the request without markers.
+func (p *Publisher) Publish(ctx context.Context, request *pb.ParseRequest) (*Report, error) {
+	report := &Report{}
+
+	started := p.response(request)
+	started.Item = &pb.ParseResponse_Started{Started: &pb.ParseStarted{
+		StartedAt: timestamppb.New(p.now()),
+	}}
+
+	if err := p.producer.Produce(ctx, p.message(request, started)); err != nil {
+		report.Status = pb.ParseStatus_PARSE_STATUS_FAILED
+		report.fail(fmt.Errorf("%w: start marker: %w", ErrProduce, err))
+
+		return report, report.Err()
+	}
+
+	read := p.publishItems(ctx, request, report)
+
+	switch {
+	case !read && report.Published == 0:
+		report.Status = pb.ParseStatus_PARSE_STATUS_FAILED
+	case !read || report.Failed > 0:
+		report.Status = pb.ParseStatus_PARSE_STATUS_PARTIAL
+	}
+
+	if err := p.producer.Produce(ctx, p.message(request, p.finished(request, report))); err != nil {
+		report.fail(fmt.Errorf("%w: end marker: %w", ErrProduce, err))
+	}
+
+	return report, report.Err()
+}
+
+// publishItems produces the entities of the file in batches and reports whether the file was read in full.
+func (p *Publisher) publishItems(
+	ctx context.Context,
+	request *pb.ParseRequest,
+	report *Report,
+) bool {
+	file, err := p.getter.Get(ctx, request.GetFileUrl())
+	if err != nil {
+		report.fail(fmt.Errorf("%w %q: %w", ErrGetFile, request.GetFileUrl(), err))
+
+		return false
+	}
+	defer file.Close()
+
+	batch := make([]*kafka.Message[*pb.ParseResponse], 0, p.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := p.producer.Produce(ctx, batch...); err != nil {
+			report.Failed += len(batch)
+			report.fail(fmt.Errorf("%w: %d messages: %w", ErrProduce, len(batch), err))
+		} else {
+			report.Published += len(batch)
+		}
+
+		batch = batch[:0]
+	}
+
+	for item, err := range p.items(ctx, file) {
+		if err != nil {
+			flush()
+			report.fail(fmt.Errorf("%w: %w", ErrParse, err))
+
+			return false
+		}
+
+		batch = append(batch, p.message(request, toPB(item, request)))
+		if len(batch) == p.batchSize {
+			flush()
+		}
+	}
+
+	flush()
+
+	return true
+}
+
+func (p *Publisher) items(ctx context.Context, file io.Reader) iter.Seq2[onec.Item, error] {
+	if sp, ok := p.parser.(onec.StreamParser); ok {
+		return sp.Stream(ctx, file, p.ids)
+	}
+
+	return func(yield func(onec.Item, error) bool) {
+		result, err := p.parser.Scan(ctx, file, p.ids)
+		if err != nil {
+			yield(onec.Item{}, err)
+
+			return
+		}
+
+		for item := range result.Items() {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (p *Publisher) finished(request *pb.ParseRequest, report *Report) *pb.ParseResponse {
+	errs := make([]string, 0, len(report.Errors))
+	for _, err := range report.Errors {
+		errs = append(errs, err.Error())
+	}
+
+	resp := p.response(request)
+	resp.Item = &pb.ParseResponse_Finished{Finished: &pb.ParseFinished{
+		Status:     report.Status,
+		Published:  uint64(report.Published), //nolint:gosec
+		Failed:     uint64(report.Failed),    //nolint:gosec
+		Errors:     errs,
+		FinishedAt: timestamppb.New(p.now()),
+	}}
+
+	return resp
+}
+
+func (p *Publisher) response(request *pb.ParseRequest) *pb.ParseResponse {
+	return &pb.ParseResponse{
+		RequestId:    request.GetRequestId(),
+		CustomerType: request.GetCustomerType(),
+		FileUrl:      request.GetFileUrl(),
+		CreatorId:    request.GetCreatorId(),
+		DebtorId:     request.DebtorId,
+	}
+}
+
+func (p *Publisher) message(
+	request *pb.ParseRequest,
+	resp *pb.ParseResponse,
+) *kafka.Message[*pb.ParseResponse] {
+	return &kafka.Message[*pb.ParseResponse]{
+		Key:   request.GetRequestId(),
+		Value: resp,
+	}
+}
+
+func toPB(item onec.Item, request *pb.ParseRequest) *pb.ParseResponse {
+	switch {
+	case item.ExchangeFile != nil:
+		return item.ExchangeFile.ToPB(request)
+	case item.Balance != nil:
+		return item.Balance.ToPB(request)
+	default:
+		return item.Document.ToPB(request)
+	}
+}
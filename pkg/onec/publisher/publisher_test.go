@@ -0,0 +1,311 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/SOTBI-LLC/sotbi.lib/pkg/api/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/kafka"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/parser"
+)
+
+var errBroker = errors.New("broker is not available")
+
+// fakeProducer records produced messages, calls listed in failOn fail.
+type fakeProducer struct {
+	messages []*kafka.Message[*pb.ParseResponse]
+	calls    int
+	failOn   map[int]bool
+}
+
+func (p *fakeProducer) Produce(_ context.Context, msgs ...*kafka.Message[*pb.ParseResponse]) error {
+	p.calls++
+	if p.failOn[p.calls] {
+		return errBroker
+	}
+
+	p.messages = append(p.messages, msgs...)
+
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	return nil
+}
+
+// kinds returns the oneof kinds of produced messages: started, file, balance, document, finished.
+func (p *fakeProducer) kinds() []string {
+	res := make([]string, 0, len(p.messages))
+
+	for _, m := range p.messages {
+		switch m.Value.GetItem().(type) {
+		case *pb.ParseResponse_Started:
+			res = append(res, "started")
+		case *pb.ParseResponse_File:
+			res = append(res, "file")
+		case *pb.ParseResponse_Balance:
+			res = append(res, "balance")
+		case *pb.ParseResponse_Document:
+			res = append(res, "document")
+		case *pb.ParseResponse_Finished:
+			res = append(res, "finished")
+		}
+	}
+
+	return res
+}
+
+func (p *fakeProducer) finished() *pb.ParseFinished {
+	return p.messages[len(p.messages)-1].Value.GetFinished()
+}
+
+type PublisherTestSuite struct {
+	suite.Suite
+
+	request *pb.ParseRequest
+}
+
+func (suite *PublisherTestSuite) SetupTest() {
+	suite.request = &pb.ParseRequest{
+		RequestId:    []byte("request-1"),
+		FileUrl:      "attachments/statement.txt",
+		CustomerType: pb.CustomerType_COUNTERPARTY,
+		CreatorId:    7,
+	}
+}
+
+func (suite *PublisherTestSuite) getter(content string) ObjectGetter {
+	return GetterFunc(func(_ context.Context, fileURL string) (io.ReadCloser, error) {
+		suite.Equal(suite.request.GetFileUrl(), fileURL)
+
+		return io.NopCloser(strings.NewReader(content)), nil
+	})
+}
+
+func (suite *PublisherTestSuite) TestPublish() {
+	prod := &fakeProducer{}
+	p := New(
+		suite.getter(statement),
+		&parser.ExchangeFile{},
+		prod,
+		ids.NewCounter(0),
+		WithBatchSize(2),
+	)
+
+	report, err := p.Publish(context.Background(), suite.request)
+	suite.Require().NoError(err)
+
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_OK, report.Status)
+	suite.Equal(4, report.Published)
+	suite.Equal(
+		[]string{"started", "file", "balance", "document", "document", "finished"},
+		prod.kinds(),
+	)
+	// маркер, два пакета по два сообщения и маркер
+	suite.Equal(4, prod.calls)
+
+	for _, m := range prod.messages {
+		suite.Equal([]byte("request-1"), m.Key)
+		suite.Equal([]byte("request-1"), m.Value.GetRequestId())
+		suite.Equal(pb.CustomerType_COUNTERPARTY, m.Value.GetCustomerType())
+		suite.Equal(uint64(7), m.Value.GetCreatorId())
+	}
+
+	balanceID := prod.messages[2].Value.GetBalance().GetId()
+	suite.Equal(balanceID, prod.messages[3].Value.GetDocument().GetAccountBalanceId())
+
+	finished := prod.finished()
+	suite.Equal(uint64(4), finished.GetPublished())
+	suite.Empty(finished.GetErrors())
+	suite.NotNil(finished.GetFinishedAt())
+}
+
+func (suite *PublisherTestSuite) TestPublishScanParser() {
+	prod := &fakeProducer{}
+	p := New(suite.getter(statement), scanOnly{}, prod, ids.NewCounter(0))
+
+	report, err := p.Publish(context.Background(), suite.request)
+	suite.Require().NoError(err)
+
+	suite.Equal(4, report.Published)
+	suite.Equal(
+		[]string{"started", "file", "balance", "document", "document", "finished"},
+		prod.kinds(),
+	)
+}
+
+func (suite *PublisherTestSuite) TestPublishBatchFailure() {
+	prod := &fakeProducer{failOn: map[int]bool{2: true}}
+	p := New(
+		suite.getter(statement),
+		&parser.ExchangeFile{},
+		prod,
+		ids.NewCounter(0),
+		WithBatchSize(2),
+	)
+
+	report, err := p.Publish(context.Background(), suite.request)
+	suite.Require().ErrorIs(err, ErrProduce)
+	suite.Require().ErrorIs(err, errBroker)
+
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_PARTIAL, report.Status)
+	suite.Equal(2, report.Published)
+	suite.Equal(2, report.Failed)
+	suite.Equal([]string{"started", "document", "document", "finished"}, prod.kinds())
+
+	finished := prod.finished()
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_PARTIAL, finished.GetStatus())
+	suite.Equal(uint64(2), finished.GetFailed())
+	suite.Len(finished.GetErrors(), 1)
+}
+
+func (suite *PublisherTestSuite) TestPublishParseFailure() {
+	prod := &fakeProducer{}
+	content := strings.Replace(statement, "Сумма=3.00", "Сумма=abc", 1)
+	p := New(
+		suite.getter(content),
+		&parser.ExchangeFile{},
+		prod,
+		ids.NewCounter(0),
+		WithBatchSize(10),
+	)
+
+	report, err := p.Publish(context.Background(), suite.request)
+	suite.Require().ErrorIs(err, ErrParse)
+
+	// прочитанные до ошибки сущности опубликованы
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_PARTIAL, report.Status)
+	suite.Equal([]string{"started", "file", "balance", "finished"}, prod.kinds())
+}
+
+func (suite *PublisherTestSuite) TestPublishGetFailure() {
+	prod := &fakeProducer{}
+	getter := GetterFunc(func(context.Context, string) (io.ReadCloser, error) {
+		return nil, errors.New("object not found")
+	})
+	p := New(getter, &parser.ExchangeFile{}, prod, ids.NewCounter(0))
+
+	report, err := p.Publish(context.Background(), suite.request)
+	suite.Require().ErrorIs(err, ErrGetFile)
+
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_FAILED, report.Status)
+	suite.Equal([]string{"started", "finished"}, prod.kinds())
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_FAILED, prod.finished().GetStatus())
+}
+
+func (suite *PublisherTestSuite) TestPublishStartFailure() {
+	prod := &fakeProducer{failOn: map[int]bool{1: true}}
+	p := New(suite.getter(statement), &parser.ExchangeFile{}, prod, ids.NewCounter(0))
+
+	report, err := p.Publish(context.Background(), suite.request)
+	suite.Require().ErrorIs(err, errBroker)
+
+	suite.Equal(pb.ParseStatus_PARSE_STATUS_FAILED, report.Status)
+	suite.Empty(prod.messages)
+}
+
+func TestPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(PublisherTestSuite))
+}
+
+func TestObjectPath(t *testing.T) {
+	cases := []struct {
+		url    string
+		bucket string
+		object string
+		err    bool
+	}{
+		{url: "statement.txt", bucket: "attachments", object: "statement.txt"},
+		{
+			url:    "attachments/2024/statement.txt",
+			bucket: "attachments",
+			object: "2024/statement.txt",
+		},
+		{url: "2024/statement.txt", bucket: "attachments", object: "2024/statement.txt"},
+		{url: "s3://files/statement.txt", bucket: "files", object: "statement.txt"},
+		{
+			url:    "https://minio.local/files/2024/statement.txt",
+			bucket: "files",
+			object: "2024/statement.txt",
+		},
+		{url: "https://minio.local/statement.txt", err: true},
+		{url: "ftp://host/statement.txt", err: true},
+		{url: "", err: true},
+	}
+
+	for _, c := range cases {
+		bucket, object, err := ObjectPath(c.url, "attachments")
+		if c.err {
+			if !errors.Is(err, ErrObjectURL) {
+				t.Errorf("ObjectPath(%q) error = %v; want ErrObjectURL", c.url, err)
+			}
+
+			continue
+		}
+
+		if err != nil || bucket != c.bucket || object != c.object {
+			t.Errorf(
+				"ObjectPath(%q) = %q, %q, %v; want %q, %q",
+				c.url,
+				bucket,
+				object,
+				err,
+				c.bucket,
+				c.object,
+			)
+		}
+	}
+}
+
+// scanOnly hides Stream of the exchange file parser.
+type scanOnly struct{}
+
+func (scanOnly) Scan(
+	ctx context.Context,
+	file io.Reader,
+	ids onec.IDAllocator,
+) (*onec.Result, error) {
+	return (&parser.ExchangeFile{}).Scan(ctx, file, ids)
+}
+
+var statement = strings.Join([]string{
+	"1CClientBankExchange",
+	"ВерсияФормата=1.03",
+	"Кодировка=Windows",
+	"ДатаНачала=01.01.2024",
+	"ДатаКонца=31.01.2024",
+	"РасчСчет=40702810000000001234",
+	"СекцияРасчСчет",
+	"ДатаНачала=01.01.2024",
+	"ДатаКонца=31.01.2024",
+	"РасчСчет=40702810000000001234",
+	"НачальныйОстаток=10.00",
+	"ВсегоПоступило=5.00",
+	"ВсегоСписано=3.00",
+	"КонечныйОстаток=12.00",
+	"КонецРасчСчет",
+	"СекцияДокумент=Платежное поручение",
+	"Номер=1",
+	"Дата=15.01.2024",
+	"Сумма=3.00",
+	"ПлательщикСчет=40702810000000001234",
+	"ДатаСписано=15.01.2024",
+	"ПолучательСчет=40702810000000005678",
+	"КонецДокумента",
+	"СекцияДокумент=Платежное поручение",
+	"Номер=2",
+	"Дата=16.01.2024",
+	"Сумма=5.00",
+	"ПлательщикСчет=40702810000000005678",
+	"ПолучательСчет=40702810000000001234",
+	"ДатаПоступило=16.01.2024",
+	"КонецДокумента",
+	"КонецФайла",
+}, "\r\n")
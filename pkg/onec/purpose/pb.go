@@ -0,0 +1,54 @@
+package purpose
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/SOTBI-LLC/sotbi.lib/pkg/api/onec"
+)
+
+func (a *Analysis) ToPB() *pb.PurposeAnalysis {
+	res := &pb.PurposeAnalysis{
+		Text:                   a.Text,
+		Category:               a.Category,
+		Contracts:              referencesToPB(a.Contracts),
+		Invoices:               referencesToPB(a.Invoices),
+		CourtCases:             a.CourtCases,
+		EnforcementProceedings: a.EnforcementProceedings,
+	}
+
+	if a.VAT != nil {
+		res.Vat = &pb.Vat{Exempt: a.VAT.Exempt, Rate: a.VAT.Rate}
+
+		if a.VAT.Amount != nil {
+			minor := a.VAT.Amount.Minor()
+			res.Vat.AmountMinor = &minor
+		}
+	}
+
+	return res
+}
+
+// AttachTo sets the analysis of the document of a response built by onec.PaymentDocument.ToPB,
+// responses with other items are left as is.
+func (a *Analysis) AttachTo(resp *pb.ParseResponse) *pb.ParseResponse {
+	if doc := resp.GetDocument(); doc != nil {
+		doc.PurposeAnalysis = a.ToPB()
+	}
+
+	return resp
+}
+
+func referencesToPB(refs []Reference) []*pb.DocumentReference {
+	res := make([]*pb.DocumentReference, 0, len(refs))
+
+	for _, r := range refs {
+		ref := &pb.DocumentReference{Number: r.Number}
+		if r.Date != nil {
+			ref.Date = timestamppb.New(*r.Date)
+		}
+
+		res = append(res, ref)
+	}
+
+	return res
+}
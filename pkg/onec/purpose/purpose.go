@@ -0,0 +1,210 @@
+// Package purpose extracts structure from the payment purpose (НазначениеПлатежа) of 1C documents:
+// VAT, contracts and invoices, court cases and enforcement proceedings, and classifies payments.
+package purpose
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+// VAT is a VAT mention of the purpose, Rate is "20", "10", "20/120" etc.
+type VAT struct {
+	Exempt bool         `json:"exempt"`
+	Rate   string       `json:"rate,omitempty"`
+	Amount *money.Money `json:"amount,omitempty"`
+}
+
+// Reference is a number and a date of a contract or an invoice.
+type Reference struct {
+	Number string     `json:"number"`
+	Date   *time.Time `json:"date,omitempty"`
+}
+
+// Analysis is the structure extracted from the payment purpose.
+type Analysis struct {
+	Text                   string      `json:"text"`
+	Category               string      `json:"category"`
+	VAT                    *VAT        `json:"vat,omitempty"`
+	Contracts              []Reference `json:"contracts,omitempty"`
+	Invoices               []Reference `json:"invoices,omitempty"`
+	CourtCases             []string    `json:"court_cases,omitempty"`
+	EnforcementProceedings []string    `json:"enforcement_proceedings,omitempty"`
+}
+
+var (
+	vatExempt = regexp.MustCompile(
+		`(?i)(без\s*(налога\s*\(?)?НДС|НДС\s*не\s*облага|не\s*облага[а-я]*\s*НДС)`,
+	)
+	// НДС (20%) 1666.67, в т.ч. НДС 20 % - 1666-67 руб., НДС 20/120: 1 666,67
+	vatMention = regexp.MustCompile(
+		`(?i)НДС[^0-9А-Яа-яЁё]{0,5}(?:(\d{1,2}(?:[.,]\d{1,2})?\s*%|\d{1,2}/1\d{2})[\s)%]*)?` +
+			`(?:[-–—:=]|сумма)?\s*(\d{1,3}(?:[ \x{00a0}]\d{3})+(?:[.,-]\d{1,2})?|\d+(?:[.,-]\d{1,2})?)?`,
+	)
+	// договору поставки № 15/2024-А от 01.02.2024, дог.N 7, контракту 12
+	contractRef = referencePattern(`договор\p{L}*|дог\.?|контракт\p{L}*`)
+	// счету № 15 от 01.02.2024, с/ф 7, счета-фактуры 12, УПД № 3
+	invoiceRef = referencePattern(`сч[её]т\p{L}*(?:-фактур\p{L}*)?|сч\.?|с/ф|сф|упд`)
+	// А40-12345/2024 арбитражных судов
+	arbitrationCase = regexp.MustCompile(`[АA]\d{2}-\d{1,7}/(?:\d{4}|\d{2})(?:-[\dА-Яа-я]+)?`)
+	// 2-1234/2024, 2а-12/24, 33-1234/2024 судов общей юрисдикции после слова "дело"
+	generalCase = regexp.MustCompile(
+		`(?i)(?:^|\P{L})(?:дел[уоа]?|д\.)\s*(?:№|N)?\s*(\d{1,2}[аa]?-\d{1,7}/(?:\d{4}|\d{2})(?:-[\dА-Яа-я]+)?)`,
+	)
+	// 12345/24/77001-ИП
+	enforcement = regexp.MustCompile(`(?i)\d{1,8}/\d{2}/\d{5}-ИП`)
+
+	wordStart  = `(?i)(?:^|\P{L})`
+	dateSuffix = `(?:\s*от\s*(\d{1,2}[./]\d{1,2}[./](?:\d{4}|\d{2})))?`
+)
+
+// referencePattern builds a pattern of a document word followed by up to two words and a number:
+// the number follows a sign (№, N) or starts with a digit, so "договор поставки № 15" is found.
+// Groups are the number after a sign, the number without it and the date.
+func referencePattern(words string) *regexp.Regexp {
+	return regexp.MustCompile(wordStart + `(?:` + words + `)(?:\s+\p{L}+){0,2}?\s*` +
+		`(?:(?:№|N|No\.?|#)\s*([^\s,;]+)|(\d[^\s,;]*))` + dateSuffix)
+}
+
+// Analyzer classifies documents with Rules in order, the first matching rule sets the category,
+// DefaultCategory is used when no rule matches.
+type Analyzer struct {
+	Rules           []Rule
+	DefaultCategory string
+}
+
+// NewAnalyzer creates an analyzer with DefaultRules.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{Rules: DefaultRules(), DefaultCategory: CategoryOther}
+}
+
+// Analyze analyzes the full purpose of the document, see onec.PaymentDocument.FullPurpose.
+func (a *Analyzer) Analyze(doc *onec.PaymentDocument) *Analysis {
+	res := Extract(doc.FullPurpose())
+	res.Category = a.classify(res, doc)
+
+	return res
+}
+
+// AnalyzeText analyzes a purpose without a document, rules matching document fields do not apply.
+func (a *Analyzer) AnalyzeText(text string) *Analysis {
+	return a.Analyze(&onec.PaymentDocument{PaymentPurpose: text})
+}
+
+func (a *Analyzer) classify(res *Analysis, doc *onec.PaymentDocument) string {
+	for i := range a.Rules {
+		if a.Rules[i].matches(res, doc) {
+			return a.Rules[i].Category
+		}
+	}
+
+	return a.DefaultCategory
+}
+
+// Extract finds VAT, references and case numbers in the text, the category is left empty.
+func Extract(text string) *Analysis {
+	text = strings.Join(strings.Fields(text), " ")
+
+	res := &Analysis{
+		Text:                   text,
+		VAT:                    extractVAT(text),
+		Contracts:              references(contractRef, text),
+		Invoices:               references(invoiceRef, text),
+		EnforcementProceedings: unique(enforcement.FindAllString(text, -1)),
+	}
+
+	res.CourtCases = unique(arbitrationCase.FindAllString(text, -1))
+	for _, m := range generalCase.FindAllStringSubmatch(text, -1) {
+		res.CourtCases = append(res.CourtCases, m[1])
+	}
+
+	res.CourtCases = unique(res.CourtCases)
+
+	return res
+}
+
+func extractVAT(text string) *VAT {
+	if vatExempt.MatchString(text) {
+		return &VAT{Exempt: true}
+	}
+
+	for _, m := range vatMention.FindAllStringSubmatch(text, -1) {
+		vat := &VAT{
+			Rate: strings.ReplaceAll(strings.TrimSpace(strings.TrimRight(m[1], "% ")), ",", "."),
+		}
+
+		if m[2] != "" {
+			// 1C часто пишет копейки через дефис: 1666-67
+			amount, err := money.Parse(strings.Replace(m[2], "-", ".", 1))
+			if err == nil {
+				vat.Amount = &amount
+			}
+		}
+
+		if vat.Rate != "" || vat.Amount != nil {
+			return vat
+		}
+	}
+
+	return nil
+}
+
+func references(re *regexp.Regexp, text string) []Reference {
+	var res []Reference
+
+	for _, m := range re.FindAllStringSubmatch(text, -1) {
+		number := strings.TrimRight(m[1]+m[2], ".)")
+
+		// номер без цифр это слово ("договор поставки"), 20 цифр это номер счета банка
+		if !strings.ContainsAny(number, "0123456789") || isAccount(number) {
+			continue
+		}
+
+		ref := Reference{Number: number}
+		if m[3] != "" {
+			ref.Date = parseDate(m[3])
+		}
+
+		if !slices.ContainsFunc(res, func(r Reference) bool { return r.Number == ref.Number }) {
+			res = append(res, ref)
+		}
+	}
+
+	return res
+}
+
+func isAccount(number string) bool {
+	if len(number) != 20 {
+		return false
+	}
+
+	return strings.Trim(number, "0123456789") == ""
+}
+
+func parseDate(s string) *time.Time {
+	s = strings.ReplaceAll(s, "/", ".")
+
+	for _, layout := range []string{"2.1.2006", "2.1.06"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+
+	return nil
+}
+
+func unique(values []string) []string {
+	var res []string
+
+	for _, v := range values {
+		if !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,156 @@
+package purpose
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/SOTBI-LLC/sotbi.lib/pkg/api/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+type PurposeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PurposeTestSuite) TestVAT() {
+	tests := []struct {
+		text   string
+		rate   string
+		amount string
+		exempt bool
+	}{
+		{"Оплата по счету 15. В т.ч. НДС (20%) 1666.67 руб.", "20", "1666.67", false},
+		{"Оплата по счету 15, в том числе НДС 20 % - 1666-67", "20", "1666.67", false},
+		{"Сумма 12000-00 в т.ч. НДС 2000-00", "", "2000.00", false},
+		{"Оплата НДС 20/120: 1 666,67", "20/120", "1666.67", false},
+		{"НДС 10%", "10", "", false},
+		{"Возврат займа. Без налога (НДС)", "", "", true},
+		{"Оплата по договору 7, НДС не облагается", "", "", true},
+	}
+
+	for _, tt := range tests {
+		vat := Extract(tt.text).VAT
+		suite.Require().NotNil(vat, tt.text)
+		suite.Equal(tt.exempt, vat.Exempt, tt.text)
+		suite.Equal(tt.rate, vat.Rate, tt.text)
+
+		if tt.amount == "" {
+			suite.Nil(vat.Amount, tt.text)
+		} else {
+			suite.Equal(money.MustParse(tt.amount), utils.FromPtr(vat.Amount), tt.text)
+		}
+	}
+
+	suite.Nil(Extract("Перевод собственных средств").VAT)
+}
+
+func (suite *PurposeTestSuite) TestReferences() {
+	a := Extract("Оплата по договору поставки № 15/2024-А от 01.02.2024 по счету 312 от 5.03.24, " +
+		"сч-ф 44. Перевод на расчетный счет 40702810000000000001")
+
+	suite.Require().Len(a.Contracts, 1)
+	suite.Equal("15/2024-А", a.Contracts[0].Number)
+	suite.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), utils.FromPtr(a.Contracts[0].Date))
+
+	suite.Require().Len(a.Invoices, 1)
+	suite.Equal("312", a.Invoices[0].Number)
+	suite.Equal(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), utils.FromPtr(a.Invoices[0].Date))
+
+	a = Extract("Оплата по дог.N 7 и контракту 12, УПД № 3, с/ф 4")
+	suite.Equal([]Reference{{Number: "7"}, {Number: "12"}}, a.Contracts)
+	suite.Equal([]Reference{{Number: "3"}, {Number: "4"}}, a.Invoices)
+
+	suite.Empty(Extract("Трансфер 5 человек").Invoices)
+}
+
+func (suite *PurposeTestSuite) TestCases() {
+	a := Extract("Взыскание по ИП 12345/24/77001-ИП по делу № А40-123456/2023, " +
+		"исп. лист по делу 2-1234/2024")
+
+	suite.Equal([]string{"12345/24/77001-ИП"}, a.EnforcementProceedings)
+	suite.Equal([]string{"А40-123456/2023", "2-1234/2024"}, a.CourtCases)
+}
+
+func (suite *PurposeTestSuite) TestClassify() {
+	analyzer := NewAnalyzer()
+
+	tests := []struct {
+		text     string
+		category string
+	}{
+		{"Взыскание по ИП 12345/24/77001-ИП", CategoryEnforcement},
+		{"Госпошлина за рассмотрение иска", CategoryCourt},
+		{"Заработная плата за март 2025, НДФЛ удержан", CategorySalary},
+		{"Страховые взносы за 1 квартал", CategoryTax},
+		{"Возврат ошибочно перечисленных средств", CategoryRefund},
+		{"Погашение основного долга по кредиту", CategoryLoan},
+		{"Арендная плата за апрель", CategoryRent},
+		{"Оплата по счету 15 от 01.03.2025", CategoryGoodsServices},
+		{"Перевод собственных средств", CategoryOther},
+	}
+
+	for _, tt := range tests {
+		suite.Equal(tt.category, analyzer.AnalyzeText(tt.text).Category, tt.text)
+	}
+
+	doc := &onec.PaymentDocument{
+		PaymentPurpose: "Единый налоговый платеж",
+		CompilerStatus: utils.ToPtr("01"),
+	}
+	suite.Equal(CategoryTax, analyzer.Analyze(doc).Category)
+}
+
+func (suite *PurposeTestSuite) TestCustomRules() {
+	rule, err := NewRule("dividends", `дивиденд`)
+	suite.Require().NoError(err)
+
+	analyzer := &Analyzer{Rules: []Rule{rule}, DefaultCategory: "unknown"}
+	suite.Equal("dividends", analyzer.AnalyzeText("Выплата ДИВИДЕНДОВ за 2024").Category)
+	suite.Equal("unknown", analyzer.AnalyzeText("Оплата по счету 1").Category)
+
+	_, err = NewRule("broken", `(`)
+	suite.Error(err)
+}
+
+func (suite *PurposeTestSuite) TestFullPurpose() {
+	doc := &onec.PaymentDocument{
+		PaymentPurpose:  "Оплата по договору 7",
+		PaymentPurpose1: utils.ToPtr("Оплата по"),
+		PaymentPurpose2: utils.ToPtr("договору 7"),
+	}
+	suite.Equal("Оплата по договору 7", doc.FullPurpose())
+
+	doc = &onec.PaymentDocument{
+		PaymentPurpose:  "Оплата по договору 7",
+		PaymentPurpose1: utils.ToPtr("НДС не облагается"),
+	}
+	suite.Equal("Оплата по договору 7 НДС не облагается", doc.FullPurpose())
+	suite.True(NewAnalyzer().Analyze(doc).VAT.Exempt)
+}
+
+func (suite *PurposeTestSuite) TestToPB() {
+	doc := &onec.PaymentDocument{
+		Summ:           money.MustParse("100"),
+		PaymentPurpose: "Оплата по счету 15 от 01.03.2025, в т.ч. НДС 20% 16-67",
+	}
+	a := NewAnalyzer().Analyze(doc)
+
+	resp := a.AttachTo(doc.ToPB(&pb.ParseRequest{RequestId: []byte("1")}))
+	analysis := resp.GetDocument().GetPurposeAnalysis()
+
+	suite.Equal(CategoryGoodsServices, analysis.GetCategory())
+	suite.Equal(int64(1667), analysis.GetVat().GetAmountMinor())
+	suite.Equal("15", analysis.GetInvoices()[0].GetNumber())
+	suite.Equal(
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		analysis.GetInvoices()[0].GetDate().AsTime(),
+	)
+}
+
+func TestPurposeTestSuite(t *testing.T) {
+	suite.Run(t, new(PurposeTestSuite))
+}
@@ -0,0 +1,106 @@
+package purpose
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+// Categories of DefaultRules.
+const (
+	CategoryEnforcement   = "enforcement"
+	CategoryCourt         = "court"
+	CategoryTax           = "tax"
+	CategorySalary        = "salary"
+	CategoryRefund        = "refund"
+	CategoryLoan          = "loan"
+	CategoryRent          = "rent"
+	CategoryGoodsServices = "goods_services"
+	CategoryOther         = "other"
+)
+
+// Rule sets Category of documents whose purpose matches Pattern (case-insensitive)
+// and for which Match returns true, an empty Pattern or a nil Match is not checked.
+type Rule struct {
+	Category string
+	Pattern  *regexp.Regexp
+	Match    func(a *Analysis, doc *onec.PaymentDocument) bool
+}
+
+// NewRule compiles a case-insensitive pattern, e.g. for rules loaded from a configuration file.
+func NewRule(category, pattern string) (Rule, error) {
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid pattern of rule %q: %w", category, err)
+	}
+
+	return Rule{Category: category, Pattern: re}, nil
+}
+
+func (r *Rule) matches(a *Analysis, doc *onec.PaymentDocument) bool {
+	if r.Pattern == nil && r.Match == nil {
+		return false
+	}
+
+	if r.Pattern != nil && !r.Pattern.MatchString(a.Text) {
+		return false
+	}
+
+	return r.Match == nil || r.Match(a, doc)
+}
+
+// DefaultRules classify payments typical for bankruptcy and debt collection analysis,
+// more specific categories go first.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Category: CategoryEnforcement,
+			Match: func(a *Analysis, _ *onec.PaymentDocument) bool {
+				return len(a.EnforcementProceedings) > 0 ||
+					enforcementWords.MatchString(a.Text)
+			},
+		},
+		{
+			Category: CategoryCourt,
+			Match: func(a *Analysis, _ *onec.PaymentDocument) bool {
+				return len(a.CourtCases) > 0 || courtWords.MatchString(a.Text)
+			},
+		},
+		{
+			Category: CategoryTax,
+			Match: func(_ *Analysis, doc *onec.PaymentDocument) bool {
+				// СтатусСоставителя заполняется только в платежах в бюджет
+				return doc.CompilerStatus != nil && *doc.CompilerStatus != ""
+			},
+		},
+		// зарплатные реестры упоминают удержанный НДФЛ, поэтому проверяются раньше слов о налогах
+		{Category: CategorySalary, Pattern: salaryWords},
+		{Category: CategoryTax, Pattern: taxWords},
+		{Category: CategoryRefund, Pattern: refundWords},
+		{Category: CategoryLoan, Pattern: loanWords},
+		{Category: CategoryRent, Pattern: rentWords},
+		{
+			Category: CategoryGoodsServices,
+			Match: func(a *Analysis, _ *onec.PaymentDocument) bool {
+				return len(a.Invoices) > 0 || len(a.Contracts) > 0 ||
+					goodsWords.MatchString(a.Text)
+			},
+		},
+	}
+}
+
+var (
+	enforcementWords = regexp.MustCompile(
+		`(?i)исполнительн\p{L}* (лист|документ|производств)|взыскан|ФССП|пристав|исп\.? ?лист`,
+	)
+	courtWords  = regexp.MustCompile(`(?i)госпошлин|государственн\p{L}* пошлин|судебн\p{L}* расход`)
+	taxWords    = regexp.MustCompile(`(?i)налог|НДФЛ|страхов\p{L}* взнос|ЕНП|пени|штраф`)
+	salaryWords = regexp.MustCompile(
+		`(?i)заработн|зарплат|з/п|зп за|оплат\p{L}* труда|отпускн|больничн|премия|аванс за \p{L}+ \d{4}`,
+	)
+	refundWords = regexp.MustCompile(`(?i)возврат|ошибочно перечисл`)
+	loanWords   = regexp.MustCompile(`(?i)займ|кредит|процент\p{L}* по`)
+	rentWords   = regexp.MustCompile(`(?i)аренд|лизинг`)
+	goodsWords  = regexp.MustCompile(`(?i)товар|услуг|работ|поставк|оплат`)
+)
@@ -0,0 +1,212 @@
+package onec
+
+import (
+	"sort"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+)
+
+// BalanceReconciliation compares the turnover of an account balance with the documents posted within its period.
+type BalanceReconciliation struct {
+	BalanceID uint64     `json:"balance_id"`
+	Account   string     `json:"account"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	// Income and WriteOff are the sums of documents credited to and debited from the account.
+	Income   money.Money `json:"income"`
+	WriteOff money.Money `json:"write_off"`
+	// IncomeDiff and WriteOffDiff are the document sums minus ВсегоПоступило and ВсегоСписано.
+	IncomeDiff   money.Money `json:"income_diff"`
+	WriteOffDiff money.Money `json:"write_off_diff"`
+	Documents    int         `json:"documents"`
+}
+
+// HasDiscrepancy reports whether the documents do not add up to the turnover of the balance.
+func (b *BalanceReconciliation) HasDiscrepancy() bool {
+	return !b.IncomeDiff.IsZero() || !b.WriteOffDiff.IsZero()
+}
+
+type ChainBreakKind string
+
+const (
+	// ChainBreakGap days between two consecutive balances are not covered by any balance.
+	ChainBreakGap ChainBreakKind = "gap"
+	// ChainBreakOverlap two consecutive balances cover the same days.
+	ChainBreakOverlap ChainBreakKind = "overlap"
+	// ChainBreakAmount КонечныйОстаток of a balance differs from НачальныйОстаток of the next one.
+	ChainBreakAmount ChainBreakKind = "amount"
+)
+
+// ChainBreak is a place where consecutive balances of an account do not continue each other.
+type ChainBreak struct {
+	Kind           ChainBreakKind `json:"kind"`
+	Account        string         `json:"account"`
+	PrevBalanceID  uint64         `json:"prev_balance_id"`
+	NextBalanceID  uint64         `json:"next_balance_id"`
+	PrevEndDate    *time.Time     `json:"prev_end_date,omitempty"`
+	NextStartDate  *time.Time     `json:"next_start_date,omitempty"`
+	PrevFinal      money.Money    `json:"prev_final"`
+	NextInitial    money.Money    `json:"next_initial"`
+	MissingDays    int            `json:"missing_days,omitempty"`
+	OverlappedDays int            `json:"overlapped_days,omitempty"`
+}
+
+// Reconciliation is the result of checking balances of an exchange file against its documents.
+type Reconciliation struct {
+	Balances []BalanceReconciliation `json:"balances"`
+	// Orphans are indexes in Result.PaymentDocuments of documents that match no balance.
+	Orphans     []int        `json:"orphans"`
+	ChainBreaks []ChainBreak `json:"chain_breaks"`
+}
+
+// Discrepancies returns balances whose turnover differs from their documents.
+func (r *Reconciliation) Discrepancies() []BalanceReconciliation {
+	res := make([]BalanceReconciliation, 0, len(r.Balances))
+
+	for _, b := range r.Balances {
+		if b.HasDiscrepancy() {
+			res = append(res, b)
+		}
+	}
+
+	return res
+}
+
+// IsConsistent reports whether the file has no discrepancies, orphan documents and chain breaks.
+func (r *Reconciliation) IsConsistent() bool {
+	return len(r.Orphans) == 0 && len(r.ChainBreaks) == 0 && len(r.Discrepancies()) == 0
+}
+
+// Reconcile sums documents for every balance and compares them with ВсегоПоступило/ВсегоСписано.
+// A document is debited from the balance of the payer account containing ДатаСписано and credited
+// to the balance of the receiver account containing ДатаПоступило, so a transfer between two
+// accounts of the file counts in both balances, unlike AccountBalanceID linking.
+func (r *Result) Reconcile() *Reconciliation {
+	res := &Reconciliation{
+		Balances:    make([]BalanceReconciliation, len(r.Remainings)),
+		Orphans:     []int{},
+		ChainBreaks: []ChainBreak{},
+	}
+
+	// позиции остатков по счету, ID остатков в собранном вручную Result могут совпадать
+	positions := make(map[string][]int, len(r.Remainings))
+
+	for i, b := range r.Remainings {
+		positions[b.Account] = append(positions[b.Account], i)
+		res.Balances[i] = BalanceReconciliation{
+			BalanceID: b.ID,
+			Account:   b.Account,
+			StartDate: b.StartDate,
+			EndDate:   b.EndDate,
+		}
+	}
+
+	locate := func(account string, date *time.Time) *BalanceReconciliation {
+		for _, i := range positions[account] {
+			if isDateInRange(date, r.Remainings[i].StartDate, r.Remainings[i].EndDate) {
+				return &res.Balances[i]
+			}
+		}
+
+		return nil
+	}
+
+	for j := range r.PaymentDocuments {
+		doc := &r.PaymentDocuments[j]
+		matched := false
+
+		if b := locate(doc.PayerAccount, doc.WrittenOffDate); b != nil {
+			b.WriteOff = b.WriteOff.Add(doc.Summ)
+			b.Documents++
+			matched = true
+		}
+
+		if b := locate(doc.ReceiverAccount, doc.IncomeDate); b != nil {
+			b.Income = b.Income.Add(doc.Summ)
+			b.Documents++
+			matched = true
+		}
+
+		if !matched {
+			res.Orphans = append(res.Orphans, j)
+		}
+	}
+
+	for i, b := range r.Remainings {
+		res.Balances[i].IncomeDiff = res.Balances[i].Income.Sub(b.Income)
+		res.Balances[i].WriteOffDiff = res.Balances[i].WriteOff.Sub(b.WriteOff)
+	}
+
+	for _, chain := range NewBalanceIndex(r.Remainings) {
+		res.ChainBreaks = append(res.ChainBreaks, chainBreaks(chain)...)
+	}
+
+	sort.Slice(res.ChainBreaks, func(i, j int) bool {
+		if res.ChainBreaks[i].Account != res.ChainBreaks[j].Account {
+			return res.ChainBreaks[i].Account < res.ChainBreaks[j].Account
+		}
+
+		return res.ChainBreaks[i].PrevBalanceID < res.ChainBreaks[j].PrevBalanceID
+	})
+
+	return res
+}
+
+// chainBreaks checks that balances of one account follow each other day to day and amount to amount.
+func chainBreaks(chain []AccountBalance) []ChainBreak {
+	dated := make([]AccountBalance, 0, len(chain))
+
+	for _, b := range chain {
+		if b.StartDate != nil && b.EndDate != nil {
+			dated = append(dated, b)
+		}
+	}
+
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].StartDate.Before(*dated[j].StartDate)
+	})
+
+	var res []ChainBreak
+
+	for i := 1; i < len(dated); i++ {
+		prev, next := dated[i-1], dated[i]
+		brk := ChainBreak{
+			Account:       prev.Account,
+			PrevBalanceID: prev.ID,
+			NextBalanceID: next.ID,
+			PrevEndDate:   prev.EndDate,
+			NextStartDate: next.StartDate,
+			PrevFinal:     prev.FinalBalance,
+			NextInitial:   next.InitialBalance,
+		}
+
+		switch days := daysBetween(*prev.EndDate, *next.StartDate); {
+		case days > 1:
+			brk.Kind = ChainBreakGap
+			brk.MissingDays = days - 1
+			res = append(res, brk)
+		case days < 1:
+			brk.Kind = ChainBreakOverlap
+			brk.OverlappedDays = 1 - days
+			res = append(res, brk)
+		}
+
+		if prev.FinalBalance != next.InitialBalance {
+			brk.Kind = ChainBreakAmount
+			brk.MissingDays, brk.OverlappedDays = 0, 0
+			res = append(res, brk)
+		}
+	}
+
+	return res
+}
+
+// daysBetween counts calendar days from a to b ignoring time of day and time zone.
+func daysBetween(a, b time.Time) int {
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return int(day(b).Sub(day(a)).Hours() / 24)
+}
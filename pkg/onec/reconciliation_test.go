@@ -0,0 +1,122 @@
+package onec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+)
+
+// суммы в тесте в копейках
+func TestReconcile(t *testing.T) {
+	date := func(day int) *time.Time {
+		t := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	const (
+		own   = "40702810001234567890"
+		other = "40702810009876543210"
+	)
+
+	result := &Result{
+		Remainings: []AccountBalance{
+			{
+				ID: 1, Account: own, StartDate: date(1), EndDate: date(1),
+				InitialBalance: 100, Income: 50, WriteOff: 30, FinalBalance: 120,
+			},
+			{
+				ID: 2, Account: own, StartDate: date(2), EndDate: date(2),
+				InitialBalance: 120, Income: 0, WriteOff: 20, FinalBalance: 100,
+			},
+			// пропущен день 3, остаток на начало не совпадает с конечным
+			{
+				ID: 3, Account: own, StartDate: date(4), EndDate: date(4),
+				InitialBalance: 90, FinalBalance: 90,
+			},
+			{
+				ID: 4, Account: other, StartDate: date(1), EndDate: date(1),
+				Income: 30, FinalBalance: 30,
+			},
+		},
+		PaymentDocuments: []PaymentDocument{
+			{Summ: 50, ReceiverAccount: own, IncomeDate: date(1)},
+			// перевод между счетами файла учитывается в обоих остатках
+			{
+				Summ:            30,
+				PayerAccount:    own,
+				WrittenOffDate:  date(1),
+				ReceiverAccount: other,
+				IncomeDate:      date(1),
+			},
+			{Summ: 15, PayerAccount: own, WrittenOffDate: date(2)},
+			{Summ: 5, PayerAccount: own, WrittenOffDate: date(3)},
+		},
+	}
+
+	rec := result.Reconcile()
+
+	if rec.IsConsistent() {
+		t.Fatal("expected inconsistent reconciliation")
+	}
+
+	expected := []struct {
+		income, writeOff, incomeDiff, writeOffDiff money.Money
+		documents                                  int
+		discrepancy                                bool
+	}{
+		{50, 30, 0, 0, 2, false},
+		{0, 15, 0, -5, 1, true},
+		{0, 0, 0, 0, 0, false},
+		{30, 0, 0, 0, 1, false},
+	}
+
+	for i, e := range expected {
+		b := rec.Balances[i]
+		if b.Income != e.income || b.WriteOff != e.writeOff ||
+			b.IncomeDiff != e.incomeDiff || b.WriteOffDiff != e.writeOffDiff ||
+			b.Documents != e.documents || b.HasDiscrepancy() != e.discrepancy {
+			t.Errorf("balance %d: got %+v", i, b)
+		}
+	}
+
+	if d := rec.Discrepancies(); len(d) != 1 || d[0].BalanceID != 2 {
+		t.Errorf("expected discrepancy for balance 2, got %+v", d)
+	}
+
+	if len(rec.Orphans) != 1 || rec.Orphans[0] != 3 {
+		t.Errorf("expected orphan document 3, got %v", rec.Orphans)
+	}
+
+	if len(rec.ChainBreaks) != 2 {
+		t.Fatalf("expected 2 chain breaks, got %+v", rec.ChainBreaks)
+	}
+
+	gap, amount := rec.ChainBreaks[0], rec.ChainBreaks[1]
+	if gap.Kind != ChainBreakGap || gap.PrevBalanceID != 2 || gap.NextBalanceID != 3 ||
+		gap.MissingDays != 1 {
+		t.Errorf("unexpected gap: %+v", gap)
+	}
+
+	if amount.Kind != ChainBreakAmount || amount.PrevFinal != 100 || amount.NextInitial != 90 {
+		t.Errorf("unexpected amount break: %+v", amount)
+	}
+}
+
+func TestReconcileOverlap(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 2)
+	next := start.AddDate(0, 0, 1)
+
+	result := &Result{
+		Remainings: []AccountBalance{
+			{Account: "1", StartDate: &next, EndDate: &end},
+			{Account: "1", StartDate: &start, EndDate: &end},
+		},
+	}
+
+	breaks := result.Reconcile().ChainBreaks
+	if len(breaks) != 1 || breaks[0].Kind != ChainBreakOverlap || breaks[0].OverlappedDays != 2 {
+		t.Errorf("expected overlap of 2 days, got %+v", breaks)
+	}
+}
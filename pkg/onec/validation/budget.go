@@ -0,0 +1,307 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+// KBKSinglePayment is the KBK of the single tax payment (ЕНП), its other budget fields are "0".
+const KBKSinglePayment = "18201061201010000510"
+
+var (
+	// коды СтатусСоставителя по приложению 5 к приказу Минфина 107н
+	compilerStatuses = []string{
+		"01", "02", "03", "04", "05", "06", "07", "08", "09", "10",
+		"11", "12", "13", "14", "15", "16", "17", "18", "19", "20",
+		"21", "22", "23", "24", "25", "26", "27", "28", "29", "30",
+		"31", "33", "35", "36",
+	}
+	// коды ПоказательОснования
+	basisCodes = []string{
+		"ТП", "ЗД", "БФ", "ТР", "РС", "ОТ", "РТ", "ПБ", "ПР", "АП",
+		"АР", "ИН", "ТЛ", "ЗТ", "ДЕ", "ПО", "КТ", "ИД", "ИП", "ТУ",
+		"БД", "КП", "ВУ", "ДЛ", "ПК", "КВ", "ТГ", "ДВ", "РК",
+	}
+	// основания, для которых номер и дата документа обязательны
+	basisWithDocument = []string{"ТР", "РС", "ОТ", "РТ", "ПР", "АП", "АР", "ИН", "ТЛ", "ПБ"}
+	// основания текущих платежей и добровольного погашения, номер документа "0"
+	basisWithoutDocument = []string{"ТП", "ЗД"}
+	// статусы таможенных платежей, ПоказательПериода содержит код таможенного органа
+	customsStatuses = []string{"06", "07"}
+	// статусы, в которых обязателен УИН (Код)
+	uinRequiredStatuses = []string{"03", "19", "20", "31"}
+	// статусы, в которых обязателен УИН или ИНН плательщика
+	uinOrINNStatuses = []string{"08", "24"}
+	// статусы налогоплательщиков-юридических лиц и налоговых агентов, агентом бывает и ИП
+	legalEntityStatuses = []string{"01", "02"}
+
+	kbkPattern    = regexp.MustCompile(`^\d{13}[0-9A-ZА-Я]{4}\d{3}$`)
+	periodPattern = regexp.MustCompile(`^(МС\.(0[1-9]|1[0-2])|КВ\.0[1-4]|ПЛ\.0[12]|ГД\.00)\.\d{4}$`)
+)
+
+// ValidCompilerStatus checks СтатусСоставителя against the list of statuses of the Ministry of Finance.
+func ValidCompilerStatus(status string) bool {
+	return slices.Contains(compilerStatuses, status)
+}
+
+// ValidKBK checks that the budget classification code has 20 characters,
+// positions 14-17 may contain letters of the revenue sub-type.
+func ValidKBK(kbk string) bool {
+	return kbkPattern.MatchString(kbk) && strings.Trim(kbk, "0") != ""
+}
+
+// ValidOKTMO checks that OKTMO has 8 or 11 digits and is not zero.
+func ValidOKTMO(oktmo string) bool {
+	return (len(oktmo) == 8 || len(oktmo) == 11) && isDigits(oktmo) &&
+		strings.Trim(oktmo, "0") != ""
+}
+
+// ValidTaxPeriod checks ПоказательПериода: a period (МС.03.2025, КВ.01.2025, ПЛ.02.2025, ГД.00.2025)
+// or a due date DD.MM.YYYY.
+func ValidTaxPeriod(period string) bool {
+	if periodPattern.MatchString(period) {
+		return true
+	}
+
+	return len(period) == 10 && onec.ParseDate(period) != nil
+}
+
+// ValidUIN checks length (20 or 25 digits) and the control digit of УИН.
+func ValidUIN(uin string) bool {
+	if (len(uin) != 20 && len(uin) != 25) || !isDigits(uin) || strings.Trim(uin, "0") == "" {
+		return false
+	}
+
+	return uinControlDigit(uin[:len(uin)-1]) == int(uin[len(uin)-1]-'0')
+}
+
+// uinControlDigit weights digits with 1..10 repeatedly and takes the sum modulo 11,
+// on remainder 10 weights are shifted by two (3..10, 1, 2...), on the second 10 the digit is 0.
+func uinControlDigit(digits string) int {
+	for _, shift := range []int{0, 2} {
+		sum := 0
+
+		for i := range len(digits) {
+			sum += int(digits[i]-'0') * ((i+shift)%10 + 1)
+		}
+
+		if rem := sum % 11; rem < 10 {
+			return rem
+		}
+	}
+
+	return 0
+}
+
+// Budget checks fields 101 and 104-110 of a payment to the budget, a document is a budget
+// payment when СтатусСоставителя is filled. Like INN and BIK checks, issues are warnings:
+// documents of a statement are already executed by the bank.
+//
+//nolint:gocyclo
+func (v *Validator) Budget(sec Section, d *onec.PaymentDocument) {
+	status := strings.TrimSpace(utils.FromPtr(d.CompilerStatus))
+	if status == "" {
+		return
+	}
+
+	f := budgetFields{
+		kbk:    value(d.IndicatorKBK),
+		oktmo:  value(d.OKATO),
+		basis:  value(d.IndicatorBasics),
+		period: value(d.IndicatorPeriod),
+		number: value(d.IndicatorNumber),
+		date:   strings.TrimSpace(d.IndicatorDateStr),
+		uin:    value(d.UIN),
+	}
+
+	if !ValidCompilerStatus(status) {
+		v.budgetIssue(sec, "СтатусСоставителя", "unknown compiler status %q", status)
+	}
+
+	v.budgetRequired(sec)
+
+	if f.kbk != "" && f.kbk != "0" && !ValidKBK(f.kbk) {
+		v.budgetIssue(sec, "ПоказательКБК", "KBK %q must have 20 characters", f.kbk)
+	}
+
+	if f.oktmo != "" && f.oktmo != "0" && !ValidOKTMO(f.oktmo) {
+		v.budgetIssue(sec, "ОКАТО", "OKTMO %q must have 8 or 11 digits", f.oktmo)
+	}
+
+	if f.basis != "" && f.basis != "0" && !slices.Contains(basisCodes, f.basis) {
+		v.budgetIssue(sec, "ПоказательОснования", "unknown payment basis %q", f.basis)
+	}
+
+	customs := slices.Contains(customsStatuses, status)
+
+	switch {
+	case f.period == "" || f.period == "0":
+	case customs && len(f.period) == 8 && isDigits(f.period):
+		// код таможенного органа
+	case !ValidTaxPeriod(f.period):
+		v.budgetIssue(
+			sec,
+			"ПоказательПериода",
+			"tax period %q must be like МС.03.2025, КВ.01.2025, ПЛ.02.2025, ГД.00.2025 or a date",
+			f.period,
+		)
+	}
+
+	if len([]rune(f.number)) > 15 {
+		v.budgetIssue(
+			sec,
+			"ПоказательНомера",
+			"document number %q is longer than 15 characters",
+			f.number,
+		)
+	}
+
+	if f.date != "" && f.date != "0" && (len(f.date) != 10 || onec.ParseDate(f.date) == nil) {
+		v.budgetIssue(sec, "ПоказательДаты", "document date %q must be DD.MM.YYYY or 0", f.date)
+	}
+
+	if t := value(d.IndicatorType); t != "" && t != "0" {
+		v.budgetIssue(sec, "ПоказательТипа", "payment type %q is not used since 2015, must be 0", t)
+	}
+
+	if f.uin != "" && f.uin != "0" && !ValidUIN(f.uin) {
+		v.budgetIssue(sec, "Код", "invalid UIN %q", f.uin)
+	}
+
+	v.budgetCombinations(sec, d, status, f)
+}
+
+type budgetFields struct {
+	kbk, oktmo, basis, period, number, date, uin string
+}
+
+func (f budgetFields) hasUIN() bool {
+	return f.uin != "" && f.uin != "0"
+}
+
+// budgetCombinations checks fields that depend on each other and on the compiler status.
+func (v *Validator) budgetCombinations(
+	sec Section,
+	d *onec.PaymentDocument,
+	status string,
+	f budgetFields,
+) {
+	if f.kbk == KBKSinglePayment {
+		// поля в порядке реквизитов платежки, чтобы отчет не менялся от запуска к запуску
+		for _, field := range []struct{ key, val string }{
+			{"ОКАТО", f.oktmo},
+			{"ПоказательОснования", f.basis},
+			{"ПоказательПериода", f.period},
+			{"ПоказательНомера", f.number},
+			{"ПоказательДаты", f.date},
+		} {
+			if field.val != "0" && field.val != "" {
+				v.budgetIssue(
+					sec,
+					field.key,
+					"must be 0 for the single tax payment, got %q",
+					field.val,
+				)
+			}
+		}
+	}
+
+	if slices.Contains(basisWithoutDocument, f.basis) && f.number != "0" && f.number != "" {
+		v.budgetIssue(sec, "ПоказательНомера",
+			"document number must be 0 for payment basis %s, got %q", f.basis, f.number)
+	}
+
+	if slices.Contains(basisWithDocument, f.basis) {
+		if f.number == "" || f.number == "0" {
+			v.budgetIssue(
+				sec,
+				"ПоказательНомера",
+				"document number is required for payment basis %s",
+				f.basis,
+			)
+		}
+
+		if f.date == "" || f.date == "0" {
+			v.budgetIssue(
+				sec,
+				"ПоказательДаты",
+				"document date is required for payment basis %s",
+				f.basis,
+			)
+		}
+	}
+
+	if f.basis == "ТП" && f.period != "" && !periodPattern.MatchString(f.period) &&
+		!slices.Contains(customsStatuses, status) {
+		v.budgetIssue(
+			sec,
+			"ПоказательПериода",
+			"current payment must have a tax period, got %q",
+			f.period,
+		)
+	}
+
+	payerINN := strings.TrimSpace(d.PayerINN)
+	hasINN := payerINN != "" && payerINN != "0"
+
+	switch {
+	case slices.Contains(uinRequiredStatuses, status) && !f.hasUIN():
+		v.budgetIssue(sec, "Код", "UIN is required for compiler status %s", status)
+	case slices.Contains(uinOrINNStatuses, status) && !f.hasUIN() && !hasINN:
+		v.budgetIssue(sec, "Код", "UIN or payer INN is required for compiler status %s", status)
+	case status == "13" && !hasINN && !f.hasUIN():
+		v.budgetIssue(sec, "ПлательщикИНН", "individual payer must have INN or UIN")
+	}
+
+	if slices.Contains(legalEntityStatuses, status) && f.kbk != KBKSinglePayment {
+		kpp := value(d.PayerKPP)
+
+		switch {
+		case status == "02" && len(payerINN) == 12:
+			// налоговый агент ИП: у него нет КПП
+			if kpp != "0" {
+				v.budgetIssue(sec, "ПлательщикКПП",
+					"KPP must be 0 for an individual entrepreneur tax agent, got %q", kpp)
+			}
+		default:
+			if len(payerINN) != 10 {
+				v.budgetIssue(sec, "ПлательщикИНН",
+					"legal entity INN of 10 digits is required for compiler status %s", status)
+			}
+
+			if len(kpp) != 9 {
+				v.budgetIssue(
+					sec,
+					"ПлательщикКПП",
+					"KPP of 9 characters is required for compiler status %s",
+					status,
+				)
+			}
+		}
+	}
+}
+
+// budgetRequired checks that budget fields are present, "0" is a valid value for most of them.
+func (v *Validator) budgetRequired(sec Section) {
+	for _, key := range []string{
+		"ПоказательКБК", "ОКАТО", "ПоказательОснования",
+		"ПоказательПериода", "ПоказательНомера", "ПоказательДаты",
+	} {
+		if val, ok := sec.Fields[key]; !ok || strings.TrimSpace(val) == "" {
+			v.budgetIssue(sec, key, "budget payment field is missing, use 0 when not applicable")
+		}
+	}
+}
+
+func (v *Validator) budgetIssue(sec Section, key, format string, args ...any) {
+	v.add(sec, key, SeverityWarning, fmt.Sprintf(format, args...))
+}
+
+func value(s *string) string {
+	return strings.TrimSpace(utils.FromPtr(s))
+}
@@ -0,0 +1,339 @@
+package validation
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+func TestValidKBK(t *testing.T) {
+	cases := []struct {
+		kbk  string
+		want bool
+	}{
+		{"18201061201010000510", true},
+		{"1820106120101000051", false},
+		{"18210102010011000110", true},
+		{"15311105Б0101000120", false},
+		{"1531110500101Б000120", true},
+		{"00000000000000000000", false},
+		{"1820106120101000051a", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidKBK(c.kbk); got != c.want {
+			t.Errorf("ValidKBK(%q) = %v; want %v", c.kbk, got, c.want)
+		}
+	}
+}
+
+func TestValidOKTMO(t *testing.T) {
+	cases := []struct {
+		oktmo string
+		want  bool
+	}{
+		{"45382000", true},
+		{"45382000001", true},
+		{"4538200", false},
+		{"453820000", false},
+		{"00000000", false},
+		{"4538200a", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidOKTMO(c.oktmo); got != c.want {
+			t.Errorf("ValidOKTMO(%q) = %v; want %v", c.oktmo, got, c.want)
+		}
+	}
+}
+
+func TestValidTaxPeriod(t *testing.T) {
+	cases := []struct {
+		period string
+		want   bool
+	}{
+		{"МС.03.2025", true},
+		{"МС.13.2025", false},
+		{"КВ.04.2025", true},
+		{"КВ.05.2025", false},
+		{"ПЛ.02.2025", true},
+		{"ГД.00.2025", true},
+		{"ГД.01.2025", false},
+		{"28.03.2025", true},
+		{"31.02.2025", false},
+		{"MC.03.2025", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidTaxPeriod(c.period); got != c.want {
+			t.Errorf("ValidTaxPeriod(%q) = %v; want %v", c.period, got, c.want)
+		}
+	}
+}
+
+func TestValidUIN(t *testing.T) {
+	cases := []struct {
+		uin  string
+		want bool
+	}{
+		{"18200000000001234561", true},
+		{"18200000000001234562", false},
+		{"1881047700000000123456784", true},
+		{"1881047700000000123456780", false},
+		{"32200000000000000010", true},
+		{"00000000000000000000", false},
+		{"1820000000000123456", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidUIN(c.uin); got != c.want {
+			t.Errorf("ValidUIN(%q) = %v; want %v", c.uin, got, c.want)
+		}
+	}
+}
+
+func TestBudget(t *testing.T) {
+	singlePayment := map[string]string{
+		"СтатусСоставителя":   "01",
+		"ПоказательКБК":       KBKSinglePayment,
+		"ОКАТО":               "0",
+		"ПоказательОснования": "0",
+		"ПоказательПериода":   "0",
+		"ПоказательНомера":    "0",
+		"ПоказательДаты":      "0",
+	}
+
+	cases := []struct {
+		name   string
+		fields map[string]string
+		doc    onec.PaymentDocument
+		want   []string
+	}{
+		{
+			name:   "not a budget payment",
+			fields: map[string]string{},
+		},
+		{
+			name:   "single tax payment",
+			fields: singlePayment,
+		},
+		{
+			name: "single tax payment with details",
+			fields: with(singlePayment, map[string]string{
+				"ОКАТО":             "45382000",
+				"ПоказательПериода": "МС.03.2025",
+			}),
+			want: []string{"ОКАТО", "ПоказательПериода"},
+		},
+		{
+			name: "missing fields",
+			fields: map[string]string{
+				"СтатусСоставителя": "02",
+				"ПоказательКБК":     "18210102010011000110",
+			},
+			doc: onec.PaymentDocument{PayerINN: "7707083893", PayerKPP: utils.ToPtr("770701001")},
+			want: []string{
+				"ОКАТО", "ПоказательОснования", "ПоказательПериода", "ПоказательНомера", "ПоказательДаты",
+			},
+		},
+		{
+			name: "invalid formats",
+			fields: map[string]string{
+				"СтатусСоставителя":   "32",
+				"ПоказательКБК":       "1821010201001100011",
+				"ОКАТО":               "4538200",
+				"ПоказательОснования": "XX",
+				"ПоказательПериода":   "МС.3.2025",
+				"ПоказательНомера":    "1234567890123456",
+				"ПоказательДаты":      "28.3.2025",
+				"ПоказательТипа":      "НС",
+				"Код":                 "18200000000001234562",
+			},
+			want: []string{
+				"СтатусСоставителя", "ПоказательКБК", "ОКАТО", "ПоказательОснования", "ПоказательПериода",
+				"ПоказательНомера", "ПоказательДаты", "ПоказательТипа", "Код",
+			},
+		},
+		{
+			name: "current payment of a legal entity",
+			fields: map[string]string{
+				"СтатусСоставителя":   "01",
+				"ПоказательКБК":       "18210102010011000110",
+				"ОКАТО":               "45382000",
+				"ПоказательОснования": "ТП",
+				"ПоказательПериода":   "КВ.01.2025",
+				"ПоказательНомера":    "15",
+				"ПоказательДаты":      "0",
+			},
+			doc:  onec.PaymentDocument{PayerINN: "500100732259"},
+			want: []string{"ПоказательНомера", "ПлательщикИНН", "ПлательщикКПП"},
+		},
+		{
+			name: "tax agent individual entrepreneur",
+			fields: map[string]string{
+				"СтатусСоставителя":   "02",
+				"ПоказательКБК":       "18210102010011000110",
+				"ОКАТО":               "45382000",
+				"ПоказательОснования": "ТП",
+				"ПоказательПериода":   "МС.03.2025",
+				"ПоказательНомера":    "0",
+				"ПоказательДаты":      "0",
+			},
+			doc: onec.PaymentDocument{PayerINN: "500100732259", PayerKPP: utils.ToPtr("0")},
+		},
+		{
+			name: "tax agent individual entrepreneur with KPP",
+			fields: map[string]string{
+				"СтатусСоставителя":   "02",
+				"ПоказательКБК":       "18210102010011000110",
+				"ОКАТО":               "45382000",
+				"ПоказательОснования": "ТП",
+				"ПоказательПериода":   "МС.03.2025",
+				"ПоказательНомера":    "0",
+				"ПоказательДаты":      "0",
+			},
+			doc: onec.PaymentDocument{
+				PayerINN: "500100732259",
+				PayerKPP: utils.ToPtr("500101001"),
+			},
+			want: []string{"ПлательщикКПП"},
+		},
+		{
+			name: "demand without document",
+			fields: map[string]string{
+				"СтатусСоставителя":   "13",
+				"ПоказательКБК":       "18210601020041000110",
+				"ОКАТО":               "45382000",
+				"ПоказательОснования": "ТР",
+				"ПоказательПериода":   "0",
+				"ПоказательНомера":    "0",
+				"ПоказательДаты":      "0",
+			},
+			want: []string{"ПоказательНомера", "ПоказательДаты", "ПлательщикИНН"},
+		},
+		{
+			name: "customs payment without UIN",
+			fields: map[string]string{
+				"СтатусСоставителя":   "06",
+				"ПоказательКБК":       "15311001000010000110",
+				"ОКАТО":               "45382000",
+				"ПоказательОснования": "0",
+				"ПоказательПериода":   "10002000",
+				"ПоказательНомера":    "0",
+				"ПоказательДаты":      "0",
+			},
+		},
+		{
+			name: "bailiff payment without UIN",
+			fields: map[string]string{
+				"СтатусСоставителя":   "31",
+				"ПоказательКБК":       "0",
+				"ОКАТО":               "45382000",
+				"ПоказательОснования": "0",
+				"ПоказательПериода":   "0",
+				"ПоказательНомера":    "0",
+				"ПоказательДаты":      "0",
+				"Код":                 "0",
+			},
+			want: []string{"Код"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			doc := c.doc
+			fill(&doc, c.fields)
+
+			v := New()
+			v.Budget(Section{Name: SectionDocument, Fields: c.fields}, &doc)
+
+			var got []string
+			for _, issue := range v.Report().Issues {
+				got = append(got, issue.Field)
+			}
+
+			slices.Sort(got)
+			want := slices.Sorted(slices.Values(c.want))
+
+			if !slices.Equal(got, want) {
+				t.Errorf("issues of %v; want %v", v.Report().Issues, want)
+			}
+		})
+	}
+}
+
+func TestBudgetSinglePaymentOrder(t *testing.T) {
+	fields := map[string]string{
+		"СтатусСоставителя":   "01",
+		"ПоказательКБК":       KBKSinglePayment,
+		"ОКАТО":               "45382000",
+		"ПоказательОснования": "БФ",
+		"ПоказательПериода":   "МС.03.2025",
+		"ПоказательНомера":    "15",
+		"ПоказательДаты":      "28.03.2025",
+	}
+
+	want := []string{
+		"ОКАТО", "ПоказательОснования", "ПоказательПериода", "ПоказательНомера", "ПоказательДаты",
+	}
+
+	// порядок не зависит от обхода map
+	for range 10 {
+		var doc onec.PaymentDocument
+		fill(&doc, fields)
+
+		v := New()
+		v.Budget(Section{Name: SectionDocument, Fields: fields}, &doc)
+
+		var got []string
+		for _, issue := range v.Report().Issues {
+			got = append(got, issue.Field)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("issues %v; want %v", got, want)
+		}
+	}
+}
+
+func TestDocumentPriority(t *testing.T) {
+	v := New()
+	v.Document(Section{Name: SectionDocument, Fields: map[string]string{
+		"Номер": "1", "Дата": "01.03.2025", "Сумма": "1", "ПлательщикСчет": "1", "ПолучательСчет": "1",
+	}}, &onec.PaymentDocument{Priority: utils.ToPtr(uint(6))}, false)
+
+	if issues := v.Report().Issues; len(issues) != 1 || issues[0].Field != "Очередность" {
+		t.Errorf("issues %v; want Очередность", issues)
+	}
+}
+
+func with(base, extra map[string]string) map[string]string {
+	res := maps.Clone(base)
+	maps.Copy(res, extra)
+
+	return res
+}
+
+// fill sets budget fields of the document the way the parser decodes them.
+func fill(d *onec.PaymentDocument, fields map[string]string) {
+	ptr := func(key string) *string {
+		if v, ok := fields[key]; ok {
+			return &v
+		}
+
+		return nil
+	}
+
+	d.CompilerStatus = ptr("СтатусСоставителя")
+	d.IndicatorKBK = ptr("ПоказательКБК")
+	d.OKATO = ptr("ОКАТО")
+	d.IndicatorBasics = ptr("ПоказательОснования")
+	d.IndicatorPeriod = ptr("ПоказательПериода")
+	d.IndicatorNumber = ptr("ПоказательНомера")
+	d.IndicatorDateStr = fields["ПоказательДаты"]
+	d.IndicatorType = ptr("ПоказательТипа")
+	d.UIN = ptr("Код")
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	innValidator "github.com/tit/go-inn-validator"
+)
+
+// ValidINN checks length and control digits of a legal person (10 digits) or private person (12 digits) INN.
+func ValidINN(inn string) bool {
+	isLegal, _ := innValidator.IsLegalPersonInnValid(inn)
+	if isLegal {
+		return true
+	}
+
+	isPrivate, _ := innValidator.IsPrivatePersonInnValid(inn)
+
+	return isPrivate
+}
+
+// ValidBIK checks that BIK consists of 9 digits and starts with the Russian Federation code 04.
+func ValidBIK(bik string) bool {
+	return len(bik) == 9 && isDigits(bik) && bik[:2] == "04"
+}
+
+// ValidAccount checks the control digit of a 20-digit account (current or correspondent)
+// against the BIK of its bank, as described in the Bank of Russia regulation 579-P.
+func ValidAccount(account, bik string) bool {
+	if len(account) != 20 || !isDigits(account) || !ValidBIK(bik) {
+		return false
+	}
+
+	return accountChecksum(accountPrefix(bik)+account)%10 == 0
+}
+
+// ValidCorrAccount checks the control digit of a correspondent account of the bank with the BIK.
+func ValidCorrAccount(account, bik string) bool {
+	if len(account) != 20 || !isDigits(account) || !ValidBIK(bik) {
+		return false
+	}
+
+	return accountChecksum("0"+bik[4:6]+account)%10 == 0
+}
+
+// accountPrefix is the conditional bank number: last three digits of BIK for accounts opened
+// in credit institutions and "0" + 5-6th digits of BIK for accounts in Bank of Russia and treasury units.
+func accountPrefix(bik string) string {
+	if bik[6:] == "000" || bik[6:] == "001" || bik[6:] == "002" {
+		return "0" + bik[4:6]
+	}
+
+	return bik[6:]
+}
+
+func accountChecksum(digits string) int {
+	weights := [3]int{7, 1, 3}
+	sum := 0
+
+	for i := range len(digits) {
+		sum += int(digits[i]-'0') * weights[i%3] % 10
+	}
+
+	return sum
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestValidINN(t *testing.T) {
+	cases := []struct {
+		inn  string
+		want bool
+	}{
+		{"7706095015", false},
+		{"7707083893", true},
+		{"500100732259", true},
+		{"500100732258", false},
+		{"77070838", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidINN(c.inn); got != c.want {
+			t.Errorf("ValidINN(%q) = %v; want %v", c.inn, got, c.want)
+		}
+	}
+}
+
+func TestValidBIK(t *testing.T) {
+	cases := []struct {
+		bik  string
+		want bool
+	}{
+		{"044525593", true},
+		{"04452559", false},
+		{"144525593", false},
+		{"04452559a", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidBIK(c.bik); got != c.want {
+			t.Errorf("ValidBIK(%q) = %v; want %v", c.bik, got, c.want)
+		}
+	}
+}
+
+func TestValidCorrAccount(t *testing.T) {
+	cases := []struct {
+		account, bik string
+		want         bool
+	}{
+		{"30101810200000000593", "044525593", true},
+		{"30101810400000000225", "044525225", true},
+		{"30101810300000000593", "044525593", false},
+		{"3010181020000000059", "044525593", false},
+	}
+
+	for _, c := range cases {
+		if got := ValidCorrAccount(c.account, c.bik); got != c.want {
+			t.Errorf("ValidCorrAccount(%q, %q) = %v; want %v", c.account, c.bik, got, c.want)
+		}
+	}
+}
+
+func TestValidAccount(t *testing.T) {
+	// the 9th digit is the control one, exactly one of ten variants must pass
+	for _, bik := range []string{"044525593", "044525000"} {
+		valid := 0
+
+		for d := '0'; d <= '9'; d++ {
+			if ValidAccount("40702810"+string(d)+"00000001234", bik) {
+				valid++
+			}
+		}
+
+		if valid != 1 {
+			t.Errorf("BIK %s: %d control digits pass; want 1", bik, valid)
+		}
+	}
+
+	if ValidAccount("4070281000000000123", "044525593") {
+		t.Error("short account must be invalid")
+	}
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Issue is a single finding, Line is the 1-based line of the file (0 when not bound to a line).
+type Issue struct {
+	Line     int      `json:"line"`
+	Severity Severity `json:"severity"`
+	Section  string   `json:"section"`
+	Field    string   `json:"field,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	var b strings.Builder
+
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+
+	fmt.Fprintf(&b, "%s: %s", i.Severity, i.Section)
+
+	if i.Field != "" {
+		fmt.Fprintf(&b, ".%s", i.Field)
+	}
+
+	fmt.Fprintf(&b, ": %s", i.Message)
+
+	return b.String()
+}
+
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+func (r *Report) Add(issue Issue) {
+	r.Issues = append(r.Issues, issue)
+}
+
+func (r *Report) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) Errors() []Issue {
+	return r.filter(SeverityError)
+}
+
+func (r *Report) Warnings() []Issue {
+	return r.filter(SeverityWarning)
+}
+
+func (r *Report) filter(severity Severity) []Issue {
+	res := make([]Issue, 0, len(r.Issues))
+
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			res = append(res, issue)
+		}
+	}
+
+	return res
+}
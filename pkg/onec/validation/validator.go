@@ -0,0 +1,238 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+const (
+	SectionHeader   = "1CClientBankExchange"
+	SectionBalance  = "СекцияРасчСчет"
+	SectionDocument = "СекцияДокумент"
+)
+
+// Section is a raw section of an exchange file with the lines its keys were read from.
+type Section struct {
+	Name   string
+	Line   int
+	Fields map[string]string
+	Lines  map[string]int
+}
+
+func (s Section) line(key string) int {
+	if line, ok := s.Lines[key]; ok {
+		return line
+	}
+
+	return s.Line
+}
+
+var (
+	headerRequired   = []string{"ВерсияФормата", "Кодировка", "ДатаНачала", "ДатаКонца", "РасчСчет"}
+	balanceRequired  = []string{"ДатаНачала", "РасчСчет", "НачальныйОстаток"}
+	documentRequired = []string{"Номер", "Дата", "Сумма", "ПлательщикСчет", "ПолучательСчет"}
+
+	headerDates   = []string{"ДатаНачала", "ДатаКонца", "ДатаСоздания"}
+	balanceDates  = []string{"ДатаНачала", "ДатаКонца"}
+	documentDates = []string{
+		"Дата",
+		"ДатаСписано",
+		"ДатаПоступило",
+		"ПоказательДаты",
+		"КвитанцияДата",
+	}
+)
+
+// Validator collects issues of an exchange file section by section,
+// the header must be passed before balances and documents to check document dates against the file period.
+type Validator struct {
+	report Report
+	header *onec.ExchangeFile
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+func (v *Validator) Report() *Report {
+	return &v.report
+}
+
+// DecodeError records a section that could not be decoded at all.
+func (v *Validator) DecodeError(sec Section, err error) {
+	v.add(sec, "", SeverityError, fmt.Sprintf("section can not be decoded: %v", err))
+}
+
+func (v *Validator) Header(sec Section, f *onec.ExchangeFile) {
+	v.header = f
+
+	v.required(sec, headerRequired)
+	v.dates(sec, headerDates)
+}
+
+func (v *Validator) Balance(sec Section, b *onec.AccountBalance) {
+	v.required(sec, balanceRequired)
+	v.dates(sec, balanceDates)
+
+	if _, ok := sec.Fields["КонечныйОстаток"]; !ok {
+		return
+	}
+
+	expected := b.InitialBalance.Add(b.Income).Sub(b.WriteOff)
+	if expected != b.FinalBalance {
+		v.add(sec, "КонечныйОстаток", SeverityError, fmt.Sprintf(
+			"НачальныйОстаток + ВсегоПоступило - ВсегоСписано = %s, КонечныйОстаток = %s",
+			expected,
+			b.FinalBalance,
+		))
+	}
+}
+
+// Document checks a payment document, skipped is true when the parser drops the document.
+func (v *Validator) Document(sec Section, d *onec.PaymentDocument, skipped bool) {
+	v.required(sec, documentRequired)
+	v.dates(sec, documentDates)
+
+	if skipped {
+		v.add(sec, "Сумма", SeverityWarning, fmt.Sprintf(
+			"document %q is skipped: sum %s is not positive", d.Number, d.Summ,
+		))
+
+		return
+	}
+
+	v.inn(sec, "ПлательщикИНН", d.PayerINN)
+	v.inn(sec, "ПолучательИНН", d.ReceiverINN)
+	v.bik(sec, "ПлательщикБИК", d.PayerBIK)
+	v.bik(sec, "ПолучательБИК", d.ReceiverBIK)
+	v.account(sec, "ПлательщикРасчСчет", d.PayerCurrentAccount, d.PayerBIK)
+	v.account(sec, "ПолучательРасчСчет", d.ReceiverCurrentAccount, d.ReceiverBIK)
+	v.corrAccount(sec, "ПлательщикКорсчет", d.PayerCorrAccount, d.PayerBIK)
+	v.corrAccount(sec, "ПолучательКорсчет", d.ReceiverCorrAccount, d.ReceiverBIK)
+	v.period(sec, d)
+	v.priority(sec, d.Priority)
+	v.Budget(sec, d)
+}
+
+func (v *Validator) add(sec Section, field string, severity Severity, msg string) {
+	line := sec.Line
+	if field != "" {
+		line = sec.line(field)
+	}
+
+	v.report.Add(Issue{
+		Line:     line,
+		Severity: severity,
+		Section:  sec.Name,
+		Field:    field,
+		Message:  msg,
+	})
+}
+
+func (v *Validator) required(sec Section, keys []string) {
+	for _, key := range keys {
+		if val, ok := sec.Fields[key]; !ok || val == "" {
+			v.add(sec, key, SeverityError, "required key is missing")
+		}
+	}
+}
+
+func (v *Validator) dates(sec Section, keys []string) {
+	for _, key := range keys {
+		val := sec.Fields[key]
+		if val == "" || val == "0" {
+			continue
+		}
+
+		if onec.ParseDate(val) == nil {
+			v.add(sec, key, SeverityError, fmt.Sprintf("date %q can not be parsed", val))
+		}
+	}
+}
+
+// priority checks Очередность, the payment priority of article 855 of the Civil Code is 1-5.
+func (v *Validator) priority(sec Section, priority *uint) {
+	if priority == nil || (*priority >= 1 && *priority <= 5) {
+		return
+	}
+
+	v.add(
+		sec,
+		"Очередность",
+		SeverityWarning,
+		fmt.Sprintf("payment priority %d must be 1-5", *priority),
+	)
+}
+
+func (v *Validator) inn(sec Section, key, inn string) {
+	if inn == "" || inn == "0" || ValidINN(inn) {
+		return
+	}
+
+	v.add(sec, key, SeverityWarning, fmt.Sprintf("invalid INN %q", inn))
+}
+
+func (v *Validator) bik(sec Section, key, bik string) {
+	if bik == "" || ValidBIK(bik) {
+		return
+	}
+
+	v.add(sec, key, SeverityWarning, fmt.Sprintf("invalid BIK %q", bik))
+}
+
+func (v *Validator) account(sec Section, key, account, bik string) {
+	if account == "" || !ValidBIK(bik) || ValidAccount(account, bik) {
+		return
+	}
+
+	v.add(sec, key, SeverityWarning, fmt.Sprintf(
+		"account %q does not match control digit for BIK %s", account, bik,
+	))
+}
+
+func (v *Validator) corrAccount(sec Section, key, account, bik string) {
+	if account == "" || !ValidBIK(bik) || ValidCorrAccount(account, bik) {
+		return
+	}
+
+	v.add(sec, key, SeverityWarning, fmt.Sprintf(
+		"correspondent account %q does not match control digit for BIK %s", account, bik,
+	))
+}
+
+// period checks that the document is posted within ДатаНачала..ДатаКонца of the file.
+func (v *Validator) period(sec Section, d *onec.PaymentDocument) {
+	if v.header == nil || v.header.StartDate == nil || v.header.EndDate == nil {
+		return
+	}
+
+	key, date := "ДатаСписано", d.WrittenOffDate
+	if date == nil {
+		key, date = "ДатаПоступило", d.IncomeDate
+	}
+
+	if date == nil {
+		key, date = "Дата", d.Data
+	}
+
+	if date == nil || inPeriod(*date, *v.header.StartDate, *v.header.EndDate) {
+		return
+	}
+
+	v.add(sec, key, SeverityWarning, fmt.Sprintf(
+		"document %q date %s is outside of the file period %s - %s",
+		d.Number,
+		date.Format("02.01.2006"),
+		v.header.StartDate.Format("02.01.2006"),
+		v.header.EndDate.Format("02.01.2006"),
+	))
+}
+
+// inPeriod compares dates only, the file period end is parsed with 23:59:59 in Moscow time.
+func inPeriod(date, start, end time.Time) bool {
+	day := func(t time.Time) string { return t.Format(time.DateOnly) }
+
+	return day(date) >= day(start) && day(date) <= day(end)
+}
@@ -0,0 +1,259 @@
+package writer
+
+import (
+	"bufio"
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+)
+
+const (
+	defaultFormatVer = "1.03"
+	defaultEncoding  = "Windows"
+	lineBreak        = "\r\n"
+	dateLayout       = "02.01.2006"
+	timeLayout       = "15:04:05"
+	dateTimeLayout   = "02.01.2006 15:04:05"
+	docSectionKey    = "СекцияДокумент"
+)
+
+// ExchangeFile serializes onec.Result into the 1CClientBankExchange format.
+// Keys are taken from the same mapstructure tags the parser uses,
+// so parsing the output gives back the same result.
+// The file is written in CP866 for Кодировка=DOS and in Windows-1251 otherwise.
+type ExchangeFile struct{}
+
+var _ onec.Writer = (*ExchangeFile)(nil)
+
+func (w *ExchangeFile) Write(file io.Writer, result *onec.Result) error {
+	cm := charmap.Windows1251
+	if strings.EqualFold(strings.TrimSpace(result.ExchangeFile.Encoding), "DOS") {
+		cm = charmap.CodePage866
+	}
+
+	encoder := transform.NewWriter(file, cm.NewEncoder())
+	buf := bufio.NewWriter(encoder)
+
+	if err := w.write(buf, result); err != nil {
+		return err
+	}
+
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("error writing data: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("error writing data: %w", err)
+	}
+
+	return nil
+}
+
+func (w *ExchangeFile) write(buf *bufio.Writer, result *onec.Result) error {
+	header := headerWithDates(result.ExchangeFile)
+
+	if err := w.writeLine(buf, "1CClientBankExchange"); err != nil {
+		return err
+	}
+
+	if err := w.writeFields(buf, &header); err != nil {
+		return err
+	}
+
+	for i := range result.Remainings {
+		balance := balanceWithDates(result.Remainings[i])
+
+		if err := w.writeSection(buf, "СекцияРасчСчет", "КонецРасчСчет", &balance); err != nil {
+			return err
+		}
+	}
+
+	for i := range result.PaymentDocuments {
+		doc := documentWithDates(result.PaymentDocuments[i])
+
+		if err := w.writeSection(
+			buf,
+			docSectionKey+"="+sanitize(doc.DocumentType),
+			"КонецДокумента",
+			&doc,
+		); err != nil {
+			return err
+		}
+	}
+
+	return w.writeLine(buf, "КонецФайла")
+}
+
+func (w *ExchangeFile) writeSection(buf *bufio.Writer, begin, end string, section any) error {
+	if err := w.writeLine(buf, begin); err != nil {
+		return err
+	}
+
+	if err := w.writeFields(buf, section); err != nil {
+		return err
+	}
+
+	return w.writeLine(buf, end)
+}
+
+// writeFields writes every field tagged with mapstructure as "Key=Value".
+// Nil pointers and empty non-pointer values of omitempty fields are skipped.
+func (w *ExchangeFile) writeFields(buf *bufio.Writer, section any) error {
+	val := reflect.ValueOf(section).Elem()
+	typ := val.Type()
+
+	for i := range typ.NumField() {
+		key, omitEmpty := parseTag(typ.Field(i).Tag.Get("mapstructure"))
+		if key == "" || key == "-" || key == docSectionKey {
+			continue
+		}
+
+		field := val.Field(i)
+
+		values, ok := formatValue(field)
+		if !ok || (omitEmpty && field.Kind() != reflect.Pointer && field.IsZero()) {
+			continue
+		}
+
+		for _, v := range values {
+			if err := w.writeLine(buf, key+"="+sanitize(v)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (*ExchangeFile) writeLine(buf *bufio.Writer, line string) error {
+	if _, err := buf.WriteString(line + lineBreak); err != nil {
+		return fmt.Errorf("error writing line %q: %w", line, err)
+	}
+
+	return nil
+}
+
+func parseTag(tag string) (key string, omitEmpty bool) {
+	parts := strings.Split(tag, ",")
+
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return parts[0], omitEmpty
+}
+
+// formatValue returns string representations of the field value.
+// ok is false when the field must not be written at all.
+func formatValue(field reflect.Value) (values []string, ok bool) {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil, false
+		}
+
+		field = field.Elem()
+	}
+
+	// money.Money и другие типы со своим текстовым представлением
+	if m, isText := field.Interface().(encoding.TextMarshaler); isText {
+		text, err := m.MarshalText()
+
+		return []string{string(text)}, err == nil
+	}
+
+	switch field.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return []string{field.String()}, true
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(field.Float(), 'f', 2, 64)}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(field.Int(), 10)}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(field.Uint(), 10)}, true
+	case reflect.Slice:
+		if field.Len() == 0 {
+			return nil, false
+		}
+
+		values = make([]string, 0, field.Len())
+		for i := range field.Len() {
+			values = append(values, field.Index(i).String())
+		}
+
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+// sanitize keeps the value on one line, the format has no escaping.
+func sanitize(val string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(val)
+}
+
+// headerWithDates fills string date fields from parsed dates when the former are empty,
+// so results built in code are serialized as well as the parsed ones.
+func headerWithDates(f onec.ExchangeFile) onec.ExchangeFile {
+	if f.FormatVer == "" {
+		f.FormatVer = defaultFormatVer
+	}
+
+	if f.Encoding == "" {
+		f.Encoding = defaultEncoding
+	}
+
+	if f.CreatedDateStr == "" && f.CreatedDate != nil {
+		f.CreatedDateStr = f.CreatedDate.Format(dateLayout)
+		f.CreatedTimeStr = f.CreatedDate.Format(timeLayout)
+	}
+
+	f.StartDateStr = dateOrStr(f.StartDateStr, f.StartDate, dateLayout)
+	f.EndDateStr = dateOrStr(f.EndDateStr, f.EndDate, dateLayout)
+
+	return f
+}
+
+func balanceWithDates(b onec.AccountBalance) onec.AccountBalance {
+	b.StartDateStr = dateOrStr(b.StartDateStr, b.StartDate, dateLayout)
+	b.EndDateStr = dateOrStr(b.EndDateStr, b.EndDate, dateLayout)
+
+	return b
+}
+
+func documentWithDates(d onec.PaymentDocument) onec.PaymentDocument { //nolint:gocritic
+	d.DataStr = dateOrStr(d.DataStr, d.Data, dateLayout)
+	d.WrittenOffDateStr = dateOrStr(d.WrittenOffDateStr, d.WrittenOffDate, dateLayout)
+	d.IncomeDateStr = dateOrStr(d.IncomeDateStr, d.IncomeDate, dateLayout)
+	d.IndicatorDateStr = dateOrStr(d.IndicatorDateStr, d.IndicatorDate, dateLayout)
+
+	if d.RectDateStr == "" && d.RectDateTime != nil {
+		d.RectDateStr = d.RectDateTime.Format(dateLayout)
+		d.RectTimeStr = d.RectDateTime.Format(timeLayout)
+	}
+
+	if d.DocumentSendingDateStr == nil && d.DocumentSendingDate != nil {
+		sendingDate := d.DocumentSendingDate.Format(dateTimeLayout)
+		d.DocumentSendingDateStr = &sendingDate
+	}
+
+	return d
+}
+
+func dateOrStr(str string, date *time.Time, layout string) string {
+	if str != "" || date == nil {
+		return str
+	}
+
+	return date.Format(layout)
+}
@@ -0,0 +1,110 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/money"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/ids"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/onec/parser"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/utils"
+)
+
+type WriterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *WriterTestSuite) TestRoundTrip() {
+	file, err := os.Open("../parser/fixtures/0.txt")
+	suite.Require().NoError(err)
+
+	defer file.Close()
+
+	expected, err := (&parser.ExchangeFile{}).Scan(context.Background(), file, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	var buf bytes.Buffer
+
+	suite.Require().NoError((&ExchangeFile{}).Write(&buf, expected))
+
+	actual, err := (&parser.ExchangeFile{}).Scan(context.Background(), &buf, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	suite.Equal(expected, actual)
+}
+
+func (suite *WriterTestSuite) TestWriteBuiltResult() {
+	date := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	result := &onec.Result{
+		ExchangeFile: onec.ExchangeFile{
+			Sender:    "Бухгалтерия",
+			StartDate: &date,
+			EndDate:   &date,
+			Account:   []string{"40702810001234567890", "40702810009876543210"},
+		},
+		PaymentDocuments: []onec.PaymentDocument{
+			{
+				DocumentType:   "Платежное поручение",
+				Number:         "15",
+				Data:           &date,
+				Summ:           money.MustParse("1234.50"),
+				PayerAccount:   "40702810001234567890",
+				Payer1:         utils.ToPtr("ООО \"Ромашка\""),
+				PaymentPurpose: "Оплата по счету\nN 7",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	suite.Require().NoError((&ExchangeFile{}).Write(&buf, result))
+
+	parsed, err := (&parser.ExchangeFile{}).Scan(context.Background(), &buf, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	suite.Equal("1.03", parsed.ExchangeFile.FormatVer)
+	suite.Equal("Windows", parsed.ExchangeFile.Encoding)
+	suite.Equal("14.03.2025", parsed.ExchangeFile.StartDateStr)
+	suite.Equal(result.ExchangeFile.Account, parsed.ExchangeFile.Account)
+	suite.Require().Len(parsed.PaymentDocuments, 1)
+
+	doc := parsed.PaymentDocuments[0]
+	suite.Equal("Платежное поручение", doc.DocumentType)
+	suite.Equal("14.03.2025", doc.DataStr)
+	suite.Equal(money.MustParse("1234.5"), doc.Summ)
+	suite.Equal("ООО \"Ромашка\"", utils.FromPtr(doc.Payer1))
+	suite.Nil(doc.Payer2)
+	suite.Equal("Оплата по счету N 7", doc.PaymentPurpose)
+}
+
+func (suite *WriterTestSuite) TestWriteDOS() {
+	result := &onec.Result{
+		ExchangeFile: onec.ExchangeFile{Encoding: "DOS", Sender: "Бухгалтерия"},
+	}
+
+	var buf bytes.Buffer
+
+	suite.Require().NoError((&ExchangeFile{}).Write(&buf, result))
+
+	parsed, err := (&parser.ExchangeFile{}).Scan(context.Background(), &buf, ids.NewCounter(0))
+	suite.Require().NoError(err)
+
+	suite.Equal(onec.EncodingCP866, parsed.ExchangeFile.DetectedEncoding)
+	suite.Equal("Бухгалтерия", parsed.ExchangeFile.Sender)
+}
+
+func (suite *WriterTestSuite) TestUnsupportedRune() {
+	result := &onec.Result{ExchangeFile: onec.ExchangeFile{Sender: "🏦"}}
+
+	suite.Error((&ExchangeFile{}).Write(&bytes.Buffer{}, result))
+}
+
+func TestWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(WriterTestSuite))
+}
@@ -0,0 +1,304 @@
+package queryparams
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/raw_filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/times"
+)
+
+// number is a decimal a user types into the search, ParseFloat alone would take inf, nan, 0x1p3 or 1e5.
+var number = regexp.MustCompile(`^-?\d+([.,]\d+)?$`)
+
+// DateRange binds two parameters of days to the column: from the start of From
+// to the end of To, so timestamps of the last day are included. Either bound may be absent.
+type DateRange struct {
+	From   string
+	To     string
+	Column string
+	// Layout of the values, time.DateOnly when empty. The time of day is dropped.
+	Layout string
+	// Location of the days, Moscow when nil.
+	Location *time.Location
+	Required bool
+}
+
+// Bind implements Param.
+func (p DateRange) Bind(values url.Values) ([]Clause, error) {
+	from, hasFrom, err := p.day(values, p.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, hasTo, err := p.day(values, p.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasFrom && hasTo && to.Before(from) {
+		return nil, &Error{
+			Param: p.To,
+			Err:   fmt.Errorf("%w: %s is before %s", ErrInvalid, p.To, p.From),
+		}
+	}
+
+	var res []Clause
+
+	if hasFrom {
+		res = append(res, Clause{SQL: p.Column + " >= ?", Args: []any{from}})
+	}
+
+	if hasTo {
+		res = append(res, Clause{SQL: p.Column + " < ?", Args: []any{to.AddDate(0, 0, 1)}})
+	}
+
+	return res, nil
+}
+
+func (p DateRange) day(values url.Values, name string) (time.Time, bool, error) {
+	v, ok := first(values, name)
+	if !ok {
+		if p.Required {
+			return time.Time{}, false, &Error{Param: name, Err: ErrRequired}
+		}
+
+		return time.Time{}, false, nil
+	}
+
+	layout := p.Layout
+	if layout == "" {
+		layout = time.DateOnly
+	}
+
+	location := p.Location
+	if location == nil {
+		location = times.GetMoscowLocation()
+	}
+
+	t, err := time.ParseInLocation(layout, v, location)
+	if err != nil {
+		return time.Time{}, false, &Error{
+			Param: name,
+			Err:   fmt.Errorf("%w: %q, want %s", ErrInvalid, v, layout),
+		}
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location), true, nil
+}
+
+// IDs binds a set of IDs to column IN (…), the parameter is repeated or comma-separated.
+type IDs struct {
+	Name     string
+	Column   string
+	Required bool
+}
+
+// Bind implements Param.
+func (p IDs) Bind(values url.Values) ([]Clause, error) {
+	list := split(values, p.Name)
+	if len(list) == 0 {
+		if p.Required {
+			return nil, &Error{Param: p.Name, Err: ErrRequired}
+		}
+
+		return nil, nil
+	}
+
+	ids := make([]any, len(list))
+
+	for i, v := range list {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, &Error{Param: p.Name, Err: fmt.Errorf("%w: %q is not an ID", ErrInvalid, v)}
+		}
+
+		ids[i] = id
+	}
+
+	holders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+
+	return []Clause{{SQL: p.Column + " IN (" + holders + ")", Args: ids}}, nil
+}
+
+// Enum binds a parameter of one of the values to the clause of the value.
+type Enum struct {
+	Name     string
+	Values   map[string]Clause
+	Required bool
+}
+
+// Bind implements Param.
+func (p Enum) Bind(values url.Values) ([]Clause, error) {
+	v, ok := first(values, p.Name)
+	if !ok {
+		if p.Required {
+			return nil, &Error{Param: p.Name, Err: ErrRequired}
+		}
+
+		return nil, nil
+	}
+
+	c, ok := p.Values[v]
+	if !ok {
+		allowed := slices.Sorted(maps.Keys(p.Values))
+
+		return nil, &Error{
+			Param: p.Name,
+			Err:   fmt.Errorf("%w: %q, want one of %s", ErrInvalid, v, strings.Join(allowed, ", ")),
+		}
+	}
+
+	return []Clause{c}, nil
+}
+
+// Search binds a free-text search: a number is looked up in the Number column,
+// anything else in the Text columns case-insensitively as a substring.
+type Search struct {
+	Name   string
+	Text   []string
+	Number string
+}
+
+// Bind implements Param.
+func (p Search) Bind(values url.Values) ([]Clause, error) {
+	v, ok := first(values, p.Name)
+	if !ok {
+		return nil, nil
+	}
+
+	var node ast.Node
+
+	if p.Number != "" && number.MatchString(v) {
+		n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+		if err != nil {
+			return nil, &Error{Param: p.Name, Err: fmt.Errorf("%w: %q", ErrInvalid, v)}
+		}
+
+		node = &ast.Cond{Field: p.Number, Kind: ast.Number, Op: ast.Equals, Values: []any{n}}
+	} else {
+		if len(p.Text) == 0 {
+			return nil, &Error{Param: p.Name, Err: fmt.Errorf("%w: %q is not a number", ErrInvalid, v)}
+		}
+
+		or := &ast.Group{Logic: ast.Or}
+		for _, column := range p.Text {
+			or.Nodes = append(or.Nodes, &ast.Cond{
+				Field:  column,
+				Kind:   ast.Text,
+				Op:     ast.Contains,
+				Values: []any{v},
+			})
+		}
+
+		node = or
+	}
+
+	return []Clause{render(node)}, nil
+}
+
+// Sort binds sorts "column:asc" or "column:desc" of the schema columns,
+// the parameter is repeated or comma-separated for several sorts.
+type Sort struct {
+	Name   string
+	Schema *filtering.Schema
+}
+
+// Bind implements Param.
+func (p Sort) Bind(values url.Values) ([]Clause, error) {
+	list := split(values, p.Name)
+	sm := make(filtering.SortModel, len(list))
+
+	for i, v := range list {
+		column, sort, ok := strings.Cut(v, ":")
+		if !ok {
+			return nil, &Error{
+				Param: p.Name,
+				Err:   fmt.Errorf("%w: %q, want column:asc or column:desc", ErrInvalid, v),
+			}
+		}
+
+		sm[i] = map[string]string{"colId": column, "sort": sort}
+	}
+
+	orders, err := p.Schema.Orders(sm)
+	if err != nil {
+		return nil, &Error{Param: p.Name, Err: err}
+	}
+
+	res := make([]Clause, len(orders))
+	for i, o := range orders {
+		res[i] = Clause{Kind: OrderBy, SQL: o.String()}
+	}
+
+	return res, nil
+}
+
+// FilterModel binds the JSON filter model of AG Grid on the columns of the schema.
+type FilterModel struct {
+	Name   string
+	Schema *filtering.Schema
+}
+
+// Bind implements Param.
+func (p FilterModel) Bind(values url.Values) ([]Clause, error) {
+	v, ok := first(values, p.Name)
+	if !ok {
+		return nil, nil
+	}
+
+	fm, err := filtering.ParseJSONToFilterModel(v)
+	if err != nil {
+		return nil, &Error{Param: p.Name, Err: fmt.Errorf("%w: %w", ErrInvalid, err)}
+	}
+
+	tree, err := ast.ParseSchema(fm, p.Schema)
+	if err != nil {
+		return nil, &Error{Param: p.Name, Err: err}
+	}
+
+	res := make([]Clause, 0, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if c := render(node); c.SQL != "" {
+			res = append(res, c)
+		}
+	}
+
+	return res, nil
+}
+
+func render(node ast.Node) Clause {
+	params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
+
+	return Clause{SQL: raw_filtering.Render(node, params), Args: params.Args}
+}
+
+// first returns the first value of the parameter, ok is false when it is absent or empty.
+func first(values url.Values, name string) (string, bool) {
+	v := strings.TrimSpace(values.Get(name))
+
+	return v, v != ""
+}
+
+// split returns the values of a repeated or comma-separated parameter.
+func split(values url.Values, name string) []string {
+	var res []string
+
+	for _, v := range values[name] {
+		for part := range strings.SplitSeq(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				res = append(res, part)
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,198 @@
+// Package queryparams binds URL query parameters of list endpoints to SQL clauses.
+// A service declares its parameters and their SQL once:
+//
+//	var payments = queryparams.New(
+//		queryparams.FilterModel{Name: "filterModel", Schema: schema},
+//		queryparams.DateRange{From: "start", To: "end", Column: "pd.date", Layout: "02.01.2006 15:04:05"},
+//		queryparams.IDs{Name: "accounts", Column: "pd.bank_detail_id"},
+//		queryparams.Enum{Name: "direction", Values: map[string]queryparams.Clause{
+//			"in":  {Kind: queryparams.Having, SQL: "credit = 0"},
+//			"out": {Kind: queryparams.Having, SQL: "debet = 0"},
+//		}},
+//		queryparams.Search{Name: "query", Text: []string{"pd.payment_purpose"}, Number: "pd.summ"},
+//		queryparams.Sort{Name: "sort", Schema: schema},
+//	)
+//
+// and applies them to each request, invalid parameters are reported as Errors:
+//
+//	q, err := payments.Bind(r.URL.Query())
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//	tbl = q.Gorm(tbl)
+package queryparams
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRequired = errors.New("parameter is required")
+	ErrInvalid  = errors.New("invalid value")
+)
+
+// Kind is the part of the query a clause goes to.
+type Kind int
+
+const (
+	Where Kind = iota
+	Having
+	OrderBy
+)
+
+// Clause is an SQL fragment with ? placeholders.
+type Clause struct {
+	Kind Kind
+	SQL  string
+	Args []any
+}
+
+// Param binds query parameters to clauses, services may implement their own.
+type Param interface {
+	// Bind returns the clauses of the parameter, none when it is absent.
+	Bind(values url.Values) ([]Clause, error)
+}
+
+// Error is an invalid query parameter.
+type Error struct {
+	Param string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return e.Param + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errors are the invalid parameters of a request, the HTTP layer answers 400 Bad Request.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	res := make([]error, len(e))
+	for i, err := range e {
+		res[i] = err
+	}
+
+	return res
+}
+
+// Binder binds the declared parameters.
+type Binder struct {
+	params []Param
+}
+
+// New creates a binder of the parameters, clauses are applied in the order of parameters.
+func New(params ...Param) *Binder {
+	return &Binder{params: params}
+}
+
+// Query is the bound clauses of a request.
+type Query struct {
+	Clauses []Clause
+}
+
+// Bind checks all parameters and returns their clauses or Errors of every invalid one.
+func (b *Binder) Bind(values url.Values) (*Query, error) {
+	var (
+		q    Query
+		errs Errors
+	)
+
+	for _, p := range b.params {
+		clauses, err := p.Bind(values)
+		if err != nil {
+			var perr *Error
+			if !errors.As(err, &perr) {
+				perr = &Error{Err: err}
+			}
+
+			errs = append(errs, perr)
+
+			continue
+		}
+
+		q.Clauses = append(q.Clauses, clauses...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &q, nil
+}
+
+// Gorm applies the clauses to the query.
+func (q *Query) Gorm(tbl *gorm.DB) *gorm.DB {
+	var orders []Clause
+
+	for _, c := range q.Clauses {
+		switch c.Kind {
+		case Where:
+			tbl = tbl.Where(c.SQL, c.Args...)
+		case Having:
+			tbl = tbl.Having(c.SQL, c.Args...)
+		case OrderBy:
+			orders = append(orders, c)
+		}
+	}
+
+	return gormOrder(tbl, orders)
+}
+
+// gormOrder adds the sorts, sorts with arguments need one expression for all of them.
+func gormOrder(tbl *gorm.DB, orders []Clause) *gorm.DB {
+	expr := clause.Expr{}
+	parts := make([]string, len(orders))
+
+	for i, c := range orders {
+		parts[i] = c.SQL
+		expr.Vars = append(expr.Vars, c.Args...)
+	}
+
+	if len(expr.Vars) > 0 {
+		expr.SQL = strings.Join(parts, ", ")
+
+		return tbl.Order(clause.OrderBy{Expression: expr})
+	}
+
+	for _, order := range parts {
+		tbl = tbl.Order(order)
+	}
+
+	return tbl
+}
+
+// Squirrel applies the clauses to the query.
+func (q *Query) Squirrel(query sq.SelectBuilder) sq.SelectBuilder {
+	for _, c := range q.Clauses {
+		switch c.Kind {
+		case Where:
+			query = query.Where(c.SQL, c.Args...)
+		case Having:
+			query = query.Having(c.SQL, c.Args...)
+		case OrderBy:
+			query = query.OrderByClause(c.SQL, c.Args...)
+		}
+	}
+
+	return query
+}
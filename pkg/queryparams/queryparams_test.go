@@ -0,0 +1,181 @@
+package queryparams
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
+)
+
+var schema = &filtering.Schema{Columns: map[string]filtering.Column{
+	"date":   {Expr: "pd.date", FilterType: "date"},
+	"status": {Expr: "pd.status", FilterType: "set"},
+}}
+
+var binder = New(
+	FilterModel{Name: "filterModel", Schema: schema},
+	DateRange{From: "start", To: "end", Column: "pd.date", Layout: "02.01.2006 15:04:05"},
+	IDs{Name: "accounts", Column: "pd.bank_detail_id"},
+	Enum{Name: "direction", Values: map[string]Clause{
+		"in":  {Kind: Having, SQL: "credit = 0"},
+		"out": {Kind: Having, SQL: "debet = 0"},
+	}},
+	Search{Name: "query", Text: []string{"pd.payment_purpose", "pd.payer"}, Number: "pd.summ"},
+	Sort{Name: "sort", Schema: schema},
+)
+
+func TestBind(t *testing.T) {
+	q, err := binder.Bind(url.Values{
+		"filterModel": {`{"status": {"filterType": "set", "values": ["paid"]}}`},
+		"start":       {"01.05.2025 00:00:00"},
+		"end":         {"31.05.2025 00:00:00"},
+		"accounts":    {"3,5", "8"},
+		"direction":   {"in"},
+		"query":       {"Аренда 50%"},
+		"sort":        {"date:desc,status:asc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql, args, err := q.Squirrel(sq.Select("*").From("payment_documents_combo pd")).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "SELECT * FROM payment_documents_combo pd WHERE pd.status IN ($1) " +
+		"AND pd.date >= $2 AND pd.date < $3 AND pd.bank_detail_id IN ($4, $5, $6) " +
+		"AND (lower(pd.payment_purpose) LIKE $7 OR lower(pd.payer) LIKE $8) " +
+		"HAVING credit = 0 ORDER BY pd.date DESC, pd.status ASC"
+	if sql != want {
+		t.Errorf("got %q; want %q", sql, want)
+	}
+
+	msk := time.FixedZone("UTC+3", 3*60*60)
+	from, to := args[1].(time.Time), args[2].(time.Time)
+
+	if !from.Equal(time.Date(2025, 5, 1, 0, 0, 0, 0, msk)) ||
+		!to.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, msk)) {
+		t.Errorf("range %v - %v", from, to)
+	}
+
+	rest := []any{uint64(3), uint64(5), uint64(8), `%аренда 50\%%`, `%аренда 50\%%`}
+	if !reflect.DeepEqual(args[3:], rest) || args[0] != "paid" {
+		t.Errorf("args %#v", args)
+	}
+}
+
+func TestBindNumberSearch(t *testing.T) {
+	q, err := binder.Bind(url.Values{"query": {"1500,50"}, "end": {"31.05.2025 23:59:59"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(q.Clauses) != 2 {
+		t.Fatalf("got %+v", q.Clauses)
+	}
+
+	if c := q.Clauses[0]; c.SQL != "pd.date < ?" || c.Args[0].(time.Time).Day() != 1 {
+		t.Errorf("end: got %+v", c)
+	}
+
+	if c := q.Clauses[1]; c.SQL != "pd.summ = ?" || !reflect.DeepEqual(c.Args, []any{1500.5}) {
+		t.Errorf("query: got %+v", c)
+	}
+}
+
+func TestBindTextSearch(t *testing.T) {
+	// похожие на числа для ParseFloat строки ищутся как текст
+	for _, v := range []string{"inf", "NaN", "1e5", "0x10", "1_000", "+5", "12."} {
+		q, err := binder.Bind(url.Values{"query": {v}})
+		if err != nil {
+			t.Fatalf("%s: %v", v, err)
+		}
+
+		if len(q.Clauses) != 1 {
+			t.Fatalf("%s: got %+v", v, q.Clauses)
+		}
+
+		if c := q.Clauses[0]; c.SQL != "(lower(pd.payment_purpose) LIKE ? OR lower(pd.payer) LIKE ?)" {
+			t.Errorf("%s: got %+v", v, c)
+		}
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	_, err := binder.Bind(url.Values{
+		"filterModel": {`{"name": {"filterType": "text", "type": "equals", "filter": "x"}}`},
+		"start":       {"01.06.2025 00:00:00"},
+		"end":         {"31.05.2025 00:00:00"},
+		"accounts":    {"3,x"},
+		"direction":   {"sideways"},
+		"sort":        {"date"},
+	})
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want Errors", err)
+	}
+
+	params := make([]string, len(errs))
+	for i, e := range errs {
+		params[i] = e.Param
+	}
+
+	want := []string{"filterModel", "end", "accounts", "direction", "sort"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("got %v; want %v (%v)", params, want, err)
+	}
+
+	if !errors.Is(err, filtering.ErrColumn) || !errors.Is(err, ErrInvalid) {
+		t.Errorf("got %v", err)
+	}
+
+	_, err = New(IDs{Name: "users", Column: "user_id", Required: true}).Bind(url.Values{})
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("got %v, want ErrRequired", err)
+	}
+
+	_, err = New(Sort{Name: "sort", Schema: schema}).Bind(url.Values{"sort": {"date:up"}})
+	if !errors.Is(err, filtering.ErrSort) {
+		t.Errorf("got %v, want ErrSort", err)
+	}
+}
+
+func TestGorm(t *testing.T) {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := binder.Bind(url.Values{"accounts": {"3"}, "direction": {"out"}, "sort": {"date:asc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []map[string]any
+
+	stmt := q.Gorm(db.Table("payment_documents_combo pd")).Find(&rows).Statement
+
+	want := "SELECT * FROM payment_documents_combo pd WHERE pd.bank_detail_id IN ($1)  " +
+		"HAVING debet = 0 ORDER BY pd.date ASC"
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{uint64(3)}) {
+		t.Errorf("vars %#v", stmt.Vars)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,7 +12,9 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering"
-	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/gorm_filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/ast"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/filtering/raw_filtering"
+	"github.com/SOTBI-LLC/sotbi.lib/pkg/queryparams"
 	"github.com/SOTBI-LLC/sotbi.lib/pkg/times"
 )
 
@@ -35,81 +38,120 @@ func ToPtr[T any](t T) *T {
 	return &t
 }
 
+// makeWhereParams are the parameters of MakeWhere after the filter model.
+var makeWhereParams = []queryparams.Param{
+	queryparams.DateRange{From: "start", To: "end", Column: "date", Layout: "02.01.2006 15:04:05"},
+	queryparams.IDs{Name: "accounts", Column: "bank_detail_id"},
+	queryparams.Enum{Name: "direction", Values: map[string]queryparams.Clause{
+		"in":  {Kind: queryparams.Having, SQL: "credit = 0"},
+		"out": {Kind: queryparams.Having, SQL: "debet = 0"},
+	}},
+	legacySort{name: "sort"},
+	queryparams.Search{Name: "query", Text: []string{"payment_purpose"}, Number: "summ"},
+}
+
+// MakeWhere applies the query parameters of payment document lists,
+// invalid parameters are logged and skipped.
+//
+// Deprecated: table names and parameters are hard-coded and invalid values are ignored,
+// declare the parameters with queryparams.New and report queryparams.Errors instead.
 func MakeWhere(
 	ctx context.Context,
 	tbl *gorm.DB,
 	params url.Values,
 	useAliasForModel bool,
 ) *gorm.DB {
-	if val, ok := params["filterModel"]; ok && len(val) > 0 && val[0] != "" {
-		tableName := "payment_documents_combo"
-		if useAliasForModel {
-			tableName = "pd"
-		}
-
-		tbl = gorm_filtering.CreateFilter(ctx, tbl, val[0], tableName)
+	tableName := "payment_documents_combo"
+	if useAliasForModel {
+		tableName = "pd"
 	}
 
-	startStr, startParamFound := params["start"]
-	endStr, endParamFound := params["end"]
+	filterModel := legacyFilterModel{name: "filterModel", prefix: tableName}
 
-	if startParamFound && endParamFound {
-		start, err := time.Parse("02.01.2006 15:04:05", startStr[0])
+	// параметры проверяются по одному, чтобы ошибка одного не сбрасывала остальные, как раньше
+	for _, p := range slices.Concat([]queryparams.Param{filterModel}, makeWhereParams) {
+		q, err := queryparams.New(p).Bind(params)
 		if err != nil {
-			return tbl
-		}
+			tbl.Logger.Info(ctx, "invalid query parameter: "+err.Error())
 
-		end, err := time.Parse("02.01.2006 15:04:05", endStr[0])
-		if err != nil {
-			return tbl
+			continue
 		}
 
-		tbl = tbl.Where(
-			"date between ? and ?",
-			start.Format("2006-01-02"),
-			end.Format("2006-01-02"),
-		)
+		tbl = q.Gorm(tbl)
 	}
 
-	if val, ok := params["accounts"]; ok && len(val) > 0 && val[0] != "" {
-		if len(val) > 1 {
-			tbl = tbl.Where("bank_detail_id in (?)", val)
-		} else {
-			tbl = tbl.Where("bank_detail_id in (?)", strings.Split(val[0], ","))
-		}
+	return tbl
+}
+
+// legacyFilterModel binds the filter model on any identifier fields with the table prefix.
+type legacyFilterModel struct {
+	name   string
+	prefix string
+}
+
+func (p legacyFilterModel) Bind(values url.Values) ([]queryparams.Clause, error) {
+	v := values.Get(p.name)
+	if v == "" {
+		return nil, nil
 	}
 
-	if val, ok := params["direction"]; ok && len(val) == 1 && val[0] != "" {
-		if val[0] == "in" {
-			tbl = tbl.Having("credit = 0")
+	fm, err := filtering.ParseJSONToFilterModel(v)
+	if err != nil {
+		return nil, &queryparams.Error{
+			Param: p.name,
+			Err:   fmt.Errorf("%w: %w", queryparams.ErrInvalid, err),
 		}
+	}
 
-		if val[0] == "out" {
-			tbl = tbl.Having("debet = 0")
-		}
+	tree, err := ast.Parse(fm, p.prefix)
+	if err != nil {
+		return nil, &queryparams.Error{Param: p.name, Err: err}
 	}
 
-	if val, ok := params["sort"]; ok && len(val) > 0 && val[0] != "" {
-		for _, v := range val {
-			sort := strings.Split(v, ":")
-			tbl = tbl.Order(fmt.Sprintf("%s %s", sort[0], sort[1]))
+	res := make([]queryparams.Clause, 0, len(tree.Nodes))
+
+	for _, node := range tree.Nodes {
+		params := &raw_filtering.Params{Placeholder: raw_filtering.Question}
+		if cond := raw_filtering.Render(node, params); cond != "" {
+			res = append(res, queryparams.Clause{SQL: cond, Args: params.Args})
 		}
 	}
 
-	if query, ok := params["query"]; ok {
-		likePhrase := fmt.Sprintf("%%%s%%", query[0])
+	return res, nil
+}
+
+// legacySort binds sorts "column:asc" of any identifier columns.
+type legacySort struct {
+	name string
+}
+
+func (p legacySort) Bind(values url.Values) ([]queryparams.Clause, error) {
+	var res []queryparams.Clause
 
-		sum, err := strconv.ParseFloat(query[0], 32)
-		if err == nil {
-			tbl = tbl.
-				Where("summ = ?", sum, sum)
-		} else {
-			tbl = tbl.
-				Where("(payment_purpose LIKE ?)", likePhrase, likePhrase)
+	for _, v := range values[p.name] {
+		if v == "" {
+			continue
 		}
+
+		column, sort, _ := strings.Cut(v, ":")
+
+		desc, ok := filtering.SortDesc(sort)
+		if !ok || !filtering.ValidField(column) {
+			return nil, &queryparams.Error{
+				Param: p.name,
+				Err: fmt.Errorf(
+					"%w: %q, want column:asc or column:desc",
+					queryparams.ErrInvalid,
+					v,
+				),
+			}
+		}
+
+		order := filtering.Order{Expr: column, Desc: desc}
+		res = append(res, queryparams.Clause{Kind: queryparams.OrderBy, SQL: order.String()})
 	}
 
-	return tbl
+	return res, nil
 }
 
 // GetInterval func.
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMakeWhere(t *testing.T) {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: logger.Discard},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		params url.Values
+		want   string
+	}{
+		{
+			name: "all",
+			params: url.Values{
+				"filterModel": {`{"status": {"filterType": "set", "values": ["paid"]}}`},
+				"accounts":    {"3,5"},
+				"direction":   {"in"},
+				"sort":        {"date:desc"},
+				"query":       {"100,5"},
+			},
+			want: "SELECT * FROM payment_documents_combo pd WHERE pd.status IN ($1) " +
+				"AND bank_detail_id IN ($2, $3) AND summ = $4  HAVING credit = 0 ORDER BY date DESC",
+		},
+		{
+			// раньше сортировка без двоеточия роняла MakeWhere, а с мусором попадала в SQL
+			name:   "invalid sort",
+			params: url.Values{"sort": {"name", "x:asc;drop table users", "id:asc"}},
+			want:   "SELECT * FROM payment_documents_combo pd",
+		},
+		{
+			name:   "invalid parameter skipped",
+			params: url.Values{"accounts": {"1 or 1=1"}, "direction": {"out"}},
+			want:   "SELECT * FROM payment_documents_combo pd  HAVING debet = 0",
+		},
+	}
+
+	for _, c := range cases {
+		var rows []map[string]any
+
+		stmt := MakeWhere(
+			context.Background(),
+			db.Table("payment_documents_combo pd"),
+			c.params,
+			true,
+		).
+			Find(&rows).
+			Statement
+
+		if got := stmt.SQL.String(); got != c.want {
+			t.Errorf("%s: got %q; want %q", c.name, got, c.want)
+		}
+	}
+}